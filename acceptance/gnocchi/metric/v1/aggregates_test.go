@@ -0,0 +1,49 @@
+// +build acceptance metric aggregates
+
+package v1
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/acceptance/tools"
+	"github.com/gophercloud/utils/acceptance/clients"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/aggregates"
+)
+
+func TestAggregatesList(t *testing.T) {
+	client, err := clients.NewGnocchiV1Client()
+	if err != nil {
+		t.Fatalf("Unable to create a Gnocchi client: %v", err)
+	}
+
+	// Create a couple of metrics with measures to aggregate them.
+	metricOne, err := CreateMetric(t, client)
+	if err != nil {
+		t.Fatalf("Unable to create a Gnocchi metric: %v", err)
+	}
+	defer DeleteMetric(t, client, metricOne.ID)
+
+	metricTwo, err := CreateMetric(t, client)
+	if err != nil {
+		t.Fatalf("Unable to create a Gnocchi metric: %v", err)
+	}
+	defer DeleteMetric(t, client, metricTwo.ID)
+
+	if err := MeasuresBatchCreateMetrics(t, client, metricOne.ID, metricTwo.ID); err != nil {
+		t.Fatalf("Unable to create measures inside Gnocchi metrics: %v", err)
+	}
+
+	listOpts := aggregates.ListOpts{
+		Operations: fmt.Sprintf("(aggregate mean (metric (%s mean) (%s mean)))", metricOne.ID, metricTwo.ID),
+		Details:    true,
+	}
+	allAggregates, err := aggregates.List(client, listOpts).Extract()
+	if err != nil {
+		t.Fatalf("Unable to list aggregates: %v", err)
+	}
+
+	for _, aggregate := range allAggregates {
+		tools.PrintResource(t, aggregate)
+	}
+}
@@ -2,9 +2,11 @@ package v1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/acceptance/tools"
+	"github.com/gophercloud/utils/gnocchi"
 	"github.com/gophercloud/utils/gnocchi/metric/v1/archivepolicies"
 )
 
@@ -20,12 +22,12 @@ func CreateArchivePolicy(t *testing.T, client *gophercloud.ServiceClient) (*arch
 		},
 		Definition: []archivepolicies.ArchivePolicyDefinitionOpts{
 			{
-				Granularity: "1:00:00",
-				TimeSpan:    "30 days, 0:00:00",
+				Granularity: gnocchi.Duration(time.Hour),
+				TimeSpan:    gnocchi.Duration(30 * 24 * time.Hour),
 			},
 			{
-				Granularity: "24:00:00",
-				TimeSpan:    "90 days, 0:00:00",
+				Granularity: gnocchi.Duration(24 * time.Hour),
+				TimeSpan:    gnocchi.Duration(90 * 24 * time.Hour),
 			},
 		},
 	}
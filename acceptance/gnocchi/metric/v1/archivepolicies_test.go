@@ -4,9 +4,11 @@ package v1
 
 import (
 	"testing"
+	"time"
 
 	"github.com/gophercloud/gophercloud/acceptance/tools"
 	"github.com/gophercloud/utils/acceptance/clients"
+	"github.com/gophercloud/utils/gnocchi"
 	"github.com/gophercloud/utils/gnocchi/metric/v1/archivepolicies"
 )
 
@@ -27,12 +29,12 @@ func TestArchivePoliciesCRUD(t *testing.T) {
 	updateOpts := archivepolicies.UpdateOpts{
 		Definition: []archivepolicies.ArchivePolicyDefinitionOpts{
 			{
-				Granularity: "1:00:00",
-				TimeSpan:    "90 days, 0:00:00",
+				Granularity: gnocchi.Duration(time.Hour),
+				TimeSpan:    gnocchi.Duration(90 * 24 * time.Hour),
 			},
 			{
-				Granularity: "24:00:00",
-				TimeSpan:    "365 days, 0:00:00",
+				Granularity: gnocchi.Duration(24 * time.Hour),
+				TimeSpan:    gnocchi.Duration(365 * 24 * time.Hour),
 			},
 		},
 	}
@@ -0,0 +1,42 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/acceptance/tools"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/archivepolicyrules"
+)
+
+// CreateArchivePolicyRule will create a Gnocchi archive policy rule. An error will be returned if the
+// archive policy rule could not be created.
+func CreateArchivePolicyRule(t *testing.T, client *gophercloud.ServiceClient, archivePolicyName string) (*archivepolicyrules.ArchivePolicyRule, error) {
+	ruleName := tools.RandomString("TESTACCT-", 8)
+	createOpts := archivepolicyrules.CreateOpts{
+		ArchivePolicyName: archivePolicyName,
+		MetricPattern:     ruleName + ".*",
+		Name:              ruleName,
+	}
+
+	t.Logf("Attempting to create a Gnocchi archive policy rule")
+	archivePolicyRule, err := archivepolicyrules.Create(client, createOpts).Extract()
+	if err != nil {
+		return nil, err
+	}
+
+	t.Logf("Successfully created the Gnocchi archive policy rule.")
+	return archivePolicyRule, nil
+}
+
+// DeleteArchivePolicyRule will delete a Gnocchi archive policy rule.
+// A fatal error will occur if the delete was not successful.
+func DeleteArchivePolicyRule(t *testing.T, client *gophercloud.ServiceClient, archivePolicyRuleName string) {
+	t.Logf("Attempting to delete the Gnocchi archive policy rule: %s", archivePolicyRuleName)
+
+	err := archivepolicyrules.Delete(client, archivePolicyRuleName).ExtractErr()
+	if err != nil {
+		t.Fatalf("Unable to delete the Gnocchi archive policy rule %s: %v", archivePolicyRuleName, err)
+	}
+
+	t.Logf("Deleted the Gnocchi archive policy rule: %s", archivePolicyRuleName)
+}
@@ -0,0 +1,65 @@
+// +build acceptance metric archivepolicyrules
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/gophercloud/gophercloud/acceptance/tools"
+	"github.com/gophercloud/utils/acceptance/clients"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/archivepolicyrules"
+)
+
+func TestArchivePolicyRulesCRUD(t *testing.T) {
+	client, err := clients.NewGnocchiV1Client()
+	if err != nil {
+		t.Fatalf("Unable to create a Gnocchi client: %v", err)
+	}
+
+	archivePolicy, err := CreateArchivePolicy(t, client)
+	if err != nil {
+		t.Fatalf("Unable to create a Gnocchi archive policy: %v", err)
+	}
+	defer DeleteArchivePolicy(t, client, archivePolicy.Name)
+
+	archivePolicyRule, err := CreateArchivePolicyRule(t, client, archivePolicy.Name)
+	if err != nil {
+		t.Fatalf("Unable to create a Gnocchi archive policy rule: %v", err)
+	}
+
+	tools.PrintResource(t, archivePolicyRule)
+
+	updateOpts := archivepolicyrules.UpdateOpts{
+		Name: tools.RandomString("TESTACCT-", 8),
+	}
+	t.Logf("Attempting to rename an archive policy rule %s", archivePolicyRule.Name)
+	newArchivePolicyRule, err := archivepolicyrules.Update(client, archivePolicyRule.Name, updateOpts).Extract()
+	if err != nil {
+		DeleteArchivePolicyRule(t, client, archivePolicyRule.Name)
+		t.Fatalf("Unable to update a Gnocchi archive policy rule: %v", err)
+	}
+	defer DeleteArchivePolicyRule(t, client, newArchivePolicyRule.Name)
+
+	tools.PrintResource(t, newArchivePolicyRule)
+}
+
+func TestArchivePolicyRulesList(t *testing.T) {
+	client, err := clients.NewGnocchiV1Client()
+	if err != nil {
+		t.Fatalf("Unable to create a Gnocchi client: %v", err)
+	}
+
+	allPages, err := archivepolicyrules.List(client).AllPages()
+	if err != nil {
+		t.Fatalf("Unable to list archive policy rules: %v", err)
+	}
+
+	allArchivePolicyRules, err := archivepolicyrules.ExtractArchivePolicyRules(allPages)
+	if err != nil {
+		t.Fatalf("Unable to extract archive policy rules: %v", err)
+	}
+
+	for _, archivePolicyRule := range allArchivePolicyRules {
+		tools.PrintResource(t, archivePolicyRule)
+	}
+}
@@ -0,0 +1,39 @@
+// +build acceptance metric status
+
+package v1
+
+import (
+	"testing"
+
+	"github.com/gophercloud/gophercloud/acceptance/tools"
+	"github.com/gophercloud/utils/acceptance/clients"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/status"
+)
+
+func TestStatusGet(t *testing.T) {
+	client, err := clients.NewGnocchiV1Client()
+	if err != nil {
+		t.Fatalf("Unable to create a Gnocchi client: %v", err)
+	}
+
+	gnocchiStatus, err := status.Get(client, status.GetOpts{}).Extract()
+	if err != nil {
+		t.Fatalf("Unable to get the Gnocchi status: %v", err)
+	}
+
+	tools.PrintResource(t, gnocchiStatus)
+}
+
+func TestCapabilitiesGet(t *testing.T) {
+	client, err := clients.NewGnocchiV1Client()
+	if err != nil {
+		t.Fatalf("Unable to create a Gnocchi client: %v", err)
+	}
+
+	capabilities, err := status.GetCapabilities(client).Extract()
+	if err != nil {
+		t.Fatalf("Unable to get the Gnocchi capabilities: %v", err)
+	}
+
+	tools.PrintResource(t, capabilities)
+}
@@ -0,0 +1,219 @@
+package gnocchi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Duration represents a Gnocchi granularity or timespan.
+//
+// Gnocchi responses contain durations in a such format:
+//
+//	"0:05:00", "1:00:00", "1 day, 0:00:00", "90 days, 0:00:00"
+//
+// and requests accept durations as seconds or as strings like "1 hour" or "1d".
+type Duration time.Duration
+
+// durationClockFormat matches durations in the clock format like
+// "12:00:00", "1 day, 0:00:00" or "0:00:00.500000".
+var durationClockFormat = regexp.MustCompile(`^(?:(\d+) days?, )?(\d+):(\d{2}):(\d{2})(?:\.(\d{1,9}))?$`)
+
+// durationUnitFormat matches a single component of durations in the unit
+// format like "1h", "5min" or "2 days".
+var durationUnitFormat = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([a-zA-Z]+)\s*`)
+
+// durationUnits contains supported units of durations in the unit format.
+var durationUnits = map[string]time.Duration{
+	"ns":           time.Nanosecond,
+	"nanosecond":   time.Nanosecond,
+	"nanoseconds":  time.Nanosecond,
+	"us":           time.Microsecond,
+	"microsecond":  time.Microsecond,
+	"microseconds": time.Microsecond,
+	"ms":           time.Millisecond,
+	"millisecond":  time.Millisecond,
+	"milliseconds": time.Millisecond,
+	"s":            time.Second,
+	"sec":          time.Second,
+	"secs":         time.Second,
+	"second":       time.Second,
+	"seconds":      time.Second,
+	"m":            time.Minute,
+	"min":          time.Minute,
+	"mins":         time.Minute,
+	"minute":       time.Minute,
+	"minutes":      time.Minute,
+	"h":            time.Hour,
+	"hr":           time.Hour,
+	"hrs":          time.Hour,
+	"hour":         time.Hour,
+	"hours":        time.Hour,
+	"d":            24 * time.Hour,
+	"day":          24 * time.Hour,
+	"days":         24 * time.Hour,
+	"w":            7 * 24 * time.Hour,
+	"week":         7 * 24 * time.Hour,
+	"weeks":        7 * 24 * time.Hour,
+}
+
+// ParseDuration parses all forms of Gnocchi durations:
+//
+//	"3600", "3600.0"              - seconds;
+//	"1:00:00", "1 day, 0:00:00"   - the clock format of Gnocchi responses;
+//	"1h", "1 hour", "1d", "5min"  - the unit format, components can be combined like "1h30min".
+func ParseDuration(s string) (Duration, error) {
+	value := strings.TrimSpace(s)
+	if value == "" {
+		return 0, fmt.Errorf("got an empty Gnocchi duration")
+	}
+
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		return durationFromSeconds(seconds)
+	}
+
+	if m := durationClockFormat.FindStringSubmatch(value); m != nil {
+		fraction := m[5]
+		if fraction != "" {
+			fraction += strings.Repeat("0", 9-len(fraction))
+		}
+		components := []struct {
+			value string
+			unit  time.Duration
+		}{
+			{m[1], 24 * time.Hour},
+			{m[2], time.Hour},
+			{m[3], time.Minute},
+			{m[4], time.Second},
+			{fraction, time.Nanosecond},
+		}
+
+		var d time.Duration
+		for _, c := range components {
+			if c.value == "" {
+				continue
+			}
+			n, err := strconv.ParseInt(c.value, 10, 64)
+			if err != nil || n > math.MaxInt64/int64(c.unit) || d > math.MaxInt64-time.Duration(n)*c.unit {
+				return 0, fmt.Errorf("got a Gnocchi duration that overflows time.Duration: %q", s)
+			}
+			d += time.Duration(n) * c.unit
+		}
+		return Duration(d), nil
+	}
+
+	var d float64
+	rest := value
+	for rest != "" {
+		m := durationUnitFormat.FindStringSubmatch(rest)
+		if m == nil {
+			return 0, fmt.Errorf("got an invalid Gnocchi duration: %q", s)
+		}
+		unit, ok := durationUnits[strings.ToLower(m[2])]
+		if !ok {
+			return 0, fmt.Errorf("got an unknown unit %q of the Gnocchi duration: %q", m[2], s)
+		}
+		number, err := strconv.ParseFloat(m[1], 64)
+		if err != nil {
+			return 0, err
+		}
+		d += number * float64(unit)
+		rest = rest[len(m[0]):]
+	}
+
+	if d >= math.MaxInt64 {
+		return 0, fmt.Errorf("got a Gnocchi duration that overflows time.Duration: %q", s)
+	}
+
+	return Duration(time.Duration(d)), nil
+}
+
+// durationFromSeconds converts seconds into a Duration.
+func durationFromSeconds(seconds float64) (Duration, error) {
+	if math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+		return 0, fmt.Errorf("got a non-finite Gnocchi duration: %v", seconds)
+	}
+	if seconds < 0 {
+		return 0, fmt.Errorf("got a negative Gnocchi duration: %v", seconds)
+	}
+
+	// float64(math.MaxInt64) is rounded up to 2^63, which doesn't fit
+	// into time.Duration.
+	nanoseconds := seconds * float64(time.Second)
+	if nanoseconds >= math.MaxInt64 {
+		return 0, fmt.Errorf("got a Gnocchi duration that overflows time.Duration: %v", seconds)
+	}
+
+	return Duration(time.Duration(nanoseconds)), nil
+}
+
+// Seconds returns the duration as a floating point number of seconds,
+// which is how Gnocchi represents granularities of measures.
+func (d Duration) Seconds() float64 {
+	return time.Duration(d).Seconds()
+}
+
+// String formats the duration in the clock format of Gnocchi responses,
+// for example "0:05:00" or "1 day, 0:00:00".
+func (d Duration) String() string {
+	v := time.Duration(d)
+	days := v / (24 * time.Hour)
+	v -= days * 24 * time.Hour
+	hours := v / time.Hour
+	v -= hours * time.Hour
+	minutes := v / time.Minute
+	v -= minutes * time.Minute
+	seconds := v / time.Second
+	v -= seconds * time.Second
+
+	s := fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)
+	if v > 0 {
+		s += fmt.Sprintf(".%06d", v/time.Microsecond)
+	}
+	switch {
+	case days == 1:
+		s = "1 day, " + s
+	case days > 1:
+		s = fmt.Sprintf("%d days, %s", days, s)
+	}
+
+	return s
+}
+
+// QueryString formats the duration as seconds for query parameters of
+// Gnocchi requests.
+func (d Duration) QueryString() string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64)
+}
+
+// MarshalJSON formats the duration in the clock format of Gnocchi responses.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON parses durations that are provided as strings or as numbers
+// of seconds.
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	var err error
+	switch value := v.(type) {
+	case nil:
+		*d = 0
+	case float64:
+		*d, err = durationFromSeconds(value)
+	case string:
+		*d, err = ParseDuration(value)
+	default:
+		err = fmt.Errorf("got an invalid Gnocchi duration: %s", b)
+	}
+
+	return err
+}
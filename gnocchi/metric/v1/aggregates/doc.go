@@ -0,0 +1,45 @@
+/*
+Package aggregates provides the ability to retrieve aggregates of one or
+several metrics through the Gnocchi API.
+
+Example of Listing aggregates of known metrics
+
+	startTime := time.Date(2018, 1, 4, 10, 0, 0, 0, time.UTC)
+	listOpts := aggregates.ListOpts{
+		Operations:  "(aggregate mean (metric (9e5a6441-1044-4181-b66e-34e180753040 mean) (6dbc97c5-bfdf-47a2-b184-02e7fa348d21 mean)))",
+		Granularity: gnocchi.Duration(time.Hour),
+		Start:       &startTime,
+	}
+	allAggregates, err := aggregates.List(gnocchiClient, listOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	for _, measure := range allAggregates[0].Aggregated {
+		fmt.Printf("%+v\n", measure)
+	}
+
+Example of Listing aggregates of resources metrics grouped by a resource attribute
+
+	neededOverlap := 0.0
+	listOpts := aggregates.ListOpts{
+		Operations:    "(metric cpu.util mean)",
+		ResourceType:  "instance",
+		Search:        "server_group='my_autoscaling_group'",
+		GroupBy:       []string{"project_id"},
+		NeededOverlap: &neededOverlap,
+		Fill:          "null",
+	}
+	allAggregates, err := aggregates.List(gnocchiClient, listOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	for _, aggregate := range allAggregates {
+		fmt.Printf("%+v\n", aggregate.Group)
+		for resourceID, metrics := range aggregate.Resources {
+			fmt.Printf("%s: %+v\n", resourceID, metrics["cpu.util"]["mean"])
+		}
+	}
+*/
+package aggregates
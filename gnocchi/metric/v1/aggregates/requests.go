@@ -0,0 +1,116 @@
+package aggregates
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/utils/gnocchi"
+)
+
+// ListOptsBuilder allows extensions to add additional parameters to the
+// List request.
+type ListOptsBuilder interface {
+	// ToAggregateListMap builds a request body.
+	ToAggregateListMap() (map[string]interface{}, error)
+
+	// ToAggregateListQuery builds a query string.
+	ToAggregateListQuery() (string, error)
+}
+
+// ListOpts specifies parameters of the Gnocchi aggregates List request.
+type ListOpts struct {
+	// Operations is an expression that describes which metrics need to be
+	// aggregated and how, for example "(aggregate mean (metric cpu.util mean))".
+	Operations string `json:"operations" required:"true"`
+
+	// ResourceType is a type of the Gnocchi resources that will be searched
+	// to find metrics referenced in the Operations expression by their names.
+	ResourceType string `json:"resource_type,omitempty"`
+
+	// Search is a filter used to find Gnocchi resources of the ResourceType.
+	// It can be provided as a string filter like "server_group='my_group'" or
+	// as a value that is marshalled into a JSON filter.
+	Search interface{} `json:"search,omitempty"`
+
+	// Details allows to add references of the aggregated metrics into the response.
+	Details bool `json:"-" q:"details"`
+
+	// Start is a start of time range for the aggregates.
+	Start *time.Time `json:"-"`
+
+	// Stop is a stop of time range for the aggregates.
+	Stop *time.Time `json:"-"`
+
+	// Granularity is a needed time between two series of aggregates to retrieve.
+	Granularity gnocchi.Duration `json:"-"`
+
+	// NeededOverlap is a percentage of timestamps that should be present in
+	// every aggregated metric. Gnocchi uses 100 by default.
+	NeededOverlap *float64 `json:"-"`
+
+	// Fill is a value that is used to fill missing points of the aggregated metrics.
+	// It can be a number, "null", "ffill" or "dropna".
+	Fill string `json:"-" q:"fill"`
+
+	// GroupBy is a list of resource attributes that are used to group aggregates.
+	// It can only be used together with the ResourceType.
+	GroupBy []string `json:"-" q:"groupby"`
+}
+
+// ToAggregateListMap constructs a request body from ListOpts.
+func (opts ListOpts) ToAggregateListMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "")
+}
+
+// ToAggregateListQuery formats a ListOpts into a query string.
+func (opts ListOpts) ToAggregateListQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	if err != nil {
+		return "", err
+	}
+	params := q.Query()
+
+	if opts.Start != nil {
+		params.Add("start", opts.Start.Format(gnocchi.RFC3339NanoNoTimezone))
+	}
+
+	if opts.Stop != nil {
+		params.Add("stop", opts.Stop.Format(gnocchi.RFC3339NanoNoTimezone))
+	}
+
+	if opts.Granularity != 0 {
+		params.Add("granularity", opts.Granularity.QueryString())
+	}
+
+	if opts.NeededOverlap != nil {
+		params.Add("needed_overlap", strconv.FormatFloat(*opts.NeededOverlap, 'f', -1, 64))
+	}
+
+	q = &url.URL{RawQuery: params.Encode()}
+	return q.String(), nil
+}
+
+// List requests aggregates of one or several Gnocchi metrics that are
+// computed with the provided operations expression.
+func List(client *gophercloud.ServiceClient, opts ListOptsBuilder) (r ListResult) {
+	url := listURL(client)
+	query, err := opts.ToAggregateListQuery()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	url += query
+
+	b, err := opts.ToAggregateListMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+
+	_, r.Err = client.Post(url, b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	return
+}
@@ -0,0 +1,179 @@
+package aggregates
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/measures"
+)
+
+// aggregatedKey is a key that Gnocchi uses for measures that were computed
+// across all referenced metrics.
+const aggregatedKey = "aggregated"
+
+// ListResult represents the result of a list operation. Call its Extract
+// method to interpret it as a slice of Gnocchi aggregates.
+type ListResult struct {
+	gophercloud.Result
+}
+
+// Extract is a function that accepts a result and extracts Gnocchi aggregates.
+// Gnocchi returns a single aggregate if the request wasn't grouped and a
+// separate aggregate for every group otherwise.
+func (r ListResult) Extract() ([]Aggregate, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+
+	b, err := json.Marshal(r.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Grouped aggregates are returned as a list of groups.
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte("[")) {
+		var groups []struct {
+			Group    map[string]interface{} `json:"group"`
+			Measures Aggregate              `json:"measures"`
+		}
+		if err := json.Unmarshal(b, &groups); err != nil {
+			return nil, err
+		}
+
+		aggregates := make([]Aggregate, len(groups))
+		for i, group := range groups {
+			aggregates[i] = group.Measures
+			aggregates[i].Group = group.Group
+		}
+		return aggregates, nil
+	}
+
+	var aggregate Aggregate
+	if err := json.Unmarshal(b, &aggregate); err != nil {
+		return nil, err
+	}
+	return []Aggregate{aggregate}, nil
+}
+
+// MetricSeries represents measures of a single metric grouped by their
+// aggregation methods.
+type MetricSeries map[string][]measures.Measure
+
+// Aggregate represents a result of the Gnocchi aggregates computation.
+type Aggregate struct {
+	// Group contains values of the resource attributes that were used to
+	// group this aggregate. It's empty if the request wasn't grouped.
+	Group map[string]interface{} `json:"-"`
+
+	// Aggregated contains measures that were computed across all referenced
+	// metrics, for example with the "aggregate" operation.
+	Aggregated []measures.Measure `json:"-"`
+
+	// Metrics contains measures of the metrics that were referenced by their IDs.
+	// It's keyed by a metric ID.
+	Metrics map[string]MetricSeries `json:"-"`
+
+	// Resources contains measures of the metrics that were found via a resource search.
+	// It's keyed by a resource ID and then by a metric name.
+	Resources map[string]map[string]MetricSeries `json:"-"`
+
+	// References contains representations of the aggregated metrics or resources.
+	// It's populated only if the request was made with the Details option.
+	References []map[string]interface{} `json:"references"`
+}
+
+/*
+UnmarshalJSON helps to unmarshal response from the Gnocchi aggregates request.
+
+Gnocchi APIv1 returns aggregates in a such format:
+
+{
+    "measures": {
+        "aggregated": [
+            ["2017-01-08T10:00:00+00:00", 300.0, 146.0]
+        ],
+        "9e5a6441-1044-4181-b66e-34e180753040": {
+            "mean": [
+                ["2017-01-08T10:00:00+00:00", 300.0, 58.0]
+            ]
+        },
+        "75274f99-faf6-4112-a6d5-2794cb07c789": {
+            "cpu.util": {
+                "mean": [
+                    ["2017-01-08T10:00:00+00:00", 300.0, 12.0]
+                ]
+            }
+        }
+    },
+    "references": [...]
+}
+
+Helper splits those measures into the Aggregated, Metrics and Resources fields.
+*/
+func (r *Aggregate) UnmarshalJSON(b []byte) error {
+	type tmp Aggregate
+	var s struct {
+		tmp
+		Measures map[string]json.RawMessage `json:"measures"`
+	}
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+	*r = Aggregate(s.tmp)
+
+	for key, raw := range s.Measures {
+		if isJSONArray(raw) {
+			if key != aggregatedKey {
+				return fmt.Errorf("got unexpected measures for the %q key", key)
+			}
+			if err := json.Unmarshal(raw, &r.Aggregated); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var nested map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &nested); err != nil {
+			return err
+		}
+
+		// Metric series contain measures lists right on the second level.
+		// Resources contain metric series on the second level.
+		isMetric := true
+		for _, v := range nested {
+			isMetric = isJSONArray(v)
+			break
+		}
+
+		if isMetric {
+			var series MetricSeries
+			if err := json.Unmarshal(raw, &series); err != nil {
+				return err
+			}
+			if r.Metrics == nil {
+				r.Metrics = make(map[string]MetricSeries)
+			}
+			r.Metrics[key] = series
+			continue
+		}
+
+		var resourceSeries map[string]MetricSeries
+		if err := json.Unmarshal(raw, &resourceSeries); err != nil {
+			return err
+		}
+		if r.Resources == nil {
+			r.Resources = make(map[string]map[string]MetricSeries)
+		}
+		r.Resources[key] = resourceSeries
+	}
+
+	return nil
+}
+
+// isJSONArray checks if a raw JSON value represents an array.
+func isJSONArray(raw json.RawMessage) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(raw), []byte("["))
+}
@@ -0,0 +1,2 @@
+// aggregates unit tests
+package testing
@@ -0,0 +1,224 @@
+package testing
+
+import (
+	"time"
+
+	"github.com/gophercloud/utils/gnocchi/metric/v1/aggregates"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/measures"
+)
+
+// AggregatesListMetricsRequest represents a request to aggregate measures of known metrics.
+const AggregatesListMetricsRequest = `
+{
+    "operations": "(aggregate mean (metric (9e5a6441-1044-4181-b66e-34e180753040 mean) (6dbc97c5-bfdf-47a2-b184-02e7fa348d21 mean)))"
+}
+`
+
+// AggregatesListMetricsResult represents a raw server response to a request
+// to aggregate measures of known metrics.
+const AggregatesListMetricsResult = `
+{
+    "measures": {
+        "aggregated": [
+            [
+                "2018-01-10T12:00:00+00:00",
+                3600.0,
+                15.5
+            ],
+            [
+                "2018-01-10T13:00:00+00:00",
+                3600.0,
+                10.0
+            ]
+        ]
+    }
+}
+`
+
+// ListAggregatesMetricsExpected represents an expected response to a request
+// to aggregate measures of known metrics.
+var ListAggregatesMetricsExpected = []aggregates.Aggregate{
+	{
+		Aggregated: []measures.Measure{
+			{
+				Timestamp:   time.Date(2018, 1, 10, 12, 0, 0, 0, time.UTC),
+				Granularity: 3600.0,
+				Value:       15.5,
+			},
+			{
+				Timestamp:   time.Date(2018, 1, 10, 13, 0, 0, 0, time.UTC),
+				Granularity: 3600.0,
+				Value:       10.0,
+			},
+		},
+	},
+}
+
+// AggregatesListMetricsDetailsResult represents a raw server response to a request
+// to retrieve measures of known metrics with details.
+const AggregatesListMetricsDetailsResult = `
+{
+    "measures": {
+        "9e5a6441-1044-4181-b66e-34e180753040": {
+            "mean": [
+                [
+                    "2018-01-10T12:00:00+00:00",
+                    3600.0,
+                    15.0
+                ]
+            ]
+        }
+    },
+    "references": [
+        {
+            "id": "9e5a6441-1044-4181-b66e-34e180753040",
+            "name": "cpu.util",
+            "unit": "B/s"
+        }
+    ]
+}
+`
+
+// ListAggregatesMetricsDetailsExpected represents an expected response to a request
+// to retrieve measures of known metrics with details.
+var ListAggregatesMetricsDetailsExpected = []aggregates.Aggregate{
+	{
+		Metrics: map[string]aggregates.MetricSeries{
+			"9e5a6441-1044-4181-b66e-34e180753040": {
+				"mean": []measures.Measure{
+					{
+						Timestamp:   time.Date(2018, 1, 10, 12, 0, 0, 0, time.UTC),
+						Granularity: 3600.0,
+						Value:       15.0,
+					},
+				},
+			},
+		},
+		References: []map[string]interface{}{
+			{
+				"id":   "9e5a6441-1044-4181-b66e-34e180753040",
+				"name": "cpu.util",
+				"unit": "B/s",
+			},
+		},
+	},
+}
+
+// AggregatesListResourcesRequest represents a request to aggregate measures
+// of metrics found via a resource search.
+const AggregatesListResourcesRequest = `
+{
+    "operations": "(metric cpu.util mean)",
+    "resource_type": "instance",
+    "search": "server_group='my_autoscaling_group'"
+}
+`
+
+// AggregatesListResourcesResult represents a raw server response to a grouped
+// request to aggregate measures of metrics found via a resource search.
+const AggregatesListResourcesResult = `
+[
+    {
+        "group": {
+            "project_id": "4154f08883334e0494c41155c33c0fc9"
+        },
+        "measures": {
+            "measures": {
+                "75274f99-faf6-4112-a6d5-2794cb07c789": {
+                    "cpu.util": {
+                        "mean": [
+                            [
+                                "2018-01-10T12:00:00+00:00",
+                                3600.0,
+                                42.0
+                            ]
+                        ]
+                    }
+                }
+            }
+        }
+    },
+    {
+        "group": {
+            "project_id": "3d40ca37723449118987b9f288f4ae84"
+        },
+        "measures": {
+            "measures": {
+                "23d5d3f7-9dfa-4f73-b72b-8b0b0063ec55": {
+                    "cpu.util": {
+                        "mean": [
+                            [
+                                "2018-01-10T12:00:00+00:00",
+                                3600.0,
+                                7.5
+                            ]
+                        ]
+                    }
+                }
+            }
+        }
+    }
+]
+`
+
+// ListAggregatesResourcesExpected represents an expected response to a grouped
+// request to aggregate measures of metrics found via a resource search.
+var ListAggregatesResourcesExpected = []aggregates.Aggregate{
+	{
+		Group: map[string]interface{}{
+			"project_id": "4154f08883334e0494c41155c33c0fc9",
+		},
+		Resources: map[string]map[string]aggregates.MetricSeries{
+			"75274f99-faf6-4112-a6d5-2794cb07c789": {
+				"cpu.util": {
+					"mean": []measures.Measure{
+						{
+							Timestamp:   time.Date(2018, 1, 10, 12, 0, 0, 0, time.UTC),
+							Granularity: 3600.0,
+							Value:       42.0,
+						},
+					},
+				},
+			},
+		},
+	},
+	{
+		Group: map[string]interface{}{
+			"project_id": "3d40ca37723449118987b9f288f4ae84",
+		},
+		Resources: map[string]map[string]aggregates.MetricSeries{
+			"23d5d3f7-9dfa-4f73-b72b-8b0b0063ec55": {
+				"cpu.util": {
+					"mean": []measures.Measure{
+						{
+							Timestamp:   time.Date(2018, 1, 10, 12, 0, 0, 0, time.UTC),
+							Granularity: 3600.0,
+							Value:       7.5,
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+// AggregatesListNullFillResult represents a raw server response to a request
+// to aggregate measures with missing points filled with the "null" value.
+const AggregatesListNullFillResult = `
+{
+    "measures": {
+        "aggregated": [
+            [
+                "2018-01-10T12:00:00+00:00",
+                3600.0,
+                null
+            ],
+            [
+                "2018-01-10T13:00:00+00:00",
+                3600.0,
+                10.0
+            ]
+        ]
+    }
+}
+`
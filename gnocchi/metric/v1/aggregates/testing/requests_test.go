@@ -0,0 +1,141 @@
+package testing
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/utils/gnocchi"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/aggregates"
+	fake "github.com/gophercloud/utils/gnocchi/testhelper/client"
+)
+
+func TestListAggregatesMetrics(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/aggregates", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestJSONRequest(t, r, AggregatesListMetricsRequest)
+		th.TestFormValues(t, r, map[string]string{
+			"start":          "2018-01-10T12:00:00",
+			"stop":           "2018-01-10T14:00:05",
+			"granularity":    "3600",
+			"needed_overlap": "50.5",
+			"fill":           "ffill",
+		})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprintf(w, AggregatesListMetricsResult)
+	})
+
+	startTime := time.Date(2018, 1, 10, 12, 0, 0, 0, time.UTC)
+	stopTime := time.Date(2018, 1, 10, 14, 0, 5, 0, time.UTC)
+	neededOverlap := 50.5
+	opts := aggregates.ListOpts{
+		Operations:    "(aggregate mean (metric (9e5a6441-1044-4181-b66e-34e180753040 mean) (6dbc97c5-bfdf-47a2-b184-02e7fa348d21 mean)))",
+		Start:         &startTime,
+		Stop:          &stopTime,
+		Granularity:   gnocchi.Duration(time.Hour),
+		NeededOverlap: &neededOverlap,
+		Fill:          "ffill",
+	}
+	actual, err := aggregates.List(fake.ServiceClient(), opts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ListAggregatesMetricsExpected, actual)
+}
+
+func TestListAggregatesMetricsDetails(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/aggregates", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{
+			"details": "true",
+		})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprintf(w, AggregatesListMetricsDetailsResult)
+	})
+
+	opts := aggregates.ListOpts{
+		Operations: "(metric 9e5a6441-1044-4181-b66e-34e180753040 mean)",
+		Details:    true,
+	}
+	actual, err := aggregates.List(fake.ServiceClient(), opts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ListAggregatesMetricsDetailsExpected, actual)
+}
+
+func TestListAggregatesResources(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/aggregates", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestJSONRequest(t, r, AggregatesListResourcesRequest)
+		th.TestFormValues(t, r, map[string]string{
+			"groupby": "project_id",
+		})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprintf(w, AggregatesListResourcesResult)
+	})
+
+	opts := aggregates.ListOpts{
+		Operations:   "(metric cpu.util mean)",
+		ResourceType: "instance",
+		Search:       "server_group='my_autoscaling_group'",
+		GroupBy:      []string{"project_id"},
+	}
+	actual, err := aggregates.List(fake.ServiceClient(), opts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ListAggregatesResourcesExpected, actual)
+}
+
+func TestListAggregatesNullFill(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/aggregates", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{
+			"fill": "null",
+		})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprintf(w, AggregatesListNullFillResult)
+	})
+
+	opts := aggregates.ListOpts{
+		Operations: "(aggregate mean (metric (9e5a6441-1044-4181-b66e-34e180753040 mean) (6dbc97c5-bfdf-47a2-b184-02e7fa348d21 mean)))",
+		Fill:       "null",
+	}
+	actual, err := aggregates.List(fake.ServiceClient(), opts).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(actual))
+	th.AssertEquals(t, 2, len(actual[0].Aggregated))
+
+	// Missing points filled with the "null" value are decoded as NaN.
+	if !math.IsNaN(actual[0].Aggregated[0].Value) {
+		t.Fatalf("Expected a NaN value of a filled point, got %v", actual[0].Aggregated[0].Value)
+	}
+	th.AssertEquals(t, 10.0, actual[0].Aggregated[1].Value)
+}
@@ -0,0 +1,13 @@
+package aggregates
+
+import "github.com/gophercloud/gophercloud"
+
+const resourcePath = "aggregates"
+
+func rootURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL(resourcePath)
+}
+
+func listURL(c *gophercloud.ServiceClient) string {
+	return rootURL(c)
+}
@@ -37,12 +37,12 @@ Example of Creating an archive policy
     },
     Definition: []archivepolicies.ArchivePolicyDefinitionOpts{
       {
-        Granularity: "1:00:00",
-        TimeSpan:    "90 days, 0:00:00",
+        Granularity: gnocchi.Duration(time.Hour),
+        TimeSpan:    gnocchi.Duration(90 * 24 * time.Hour),
       },
       {
-        Granularity: "1 day, 0:00:00",
-        TimeSpan:    "100 days, 0:00:00",
+        Granularity: gnocchi.Duration(24 * time.Hour),
+        TimeSpan:    gnocchi.Duration(100 * 24 * time.Hour),
       },
     },
     Name: "test_policy",
@@ -52,17 +52,34 @@ Example of Creating an archive policy
     panic(err)
   }
 
+Example of Estimating storage of an archive policy before creating it
+
+  estimate, err := archivepolicies.EstimateStorage(createOpts)
+  if err != nil {
+    panic(err)
+  }
+
+  fmt.Printf("%d points, %d bytes per metric\n", estimate.Points, estimate.Bytes)
+  fmt.Printf("%d bytes for 1000 metrics\n", estimate.BytesForMetrics(1000))
+
+Example of Validating an archive policy definition
+
+  err := archivepolicies.ValidateDefinition(createOpts.Definition, createOpts.BackWindow)
+  if err != nil {
+    panic(err)
+  }
+
 Example of Updating an archive policy
 
   updateOpts := archivepolicies.UpdateOpts{
     Definition: []archivepolicies.ArchivePolicyDefinitionOpts{
       {
-        Granularity: "12:00:00",
-        TimeSpan:    "30 days, 0:00:00",
+        Granularity: gnocchi.Duration(12 * time.Hour),
+        TimeSpan:    gnocchi.Duration(30 * 24 * time.Hour),
       },
       {
-        Granularity: "1 day, 0:00:00",
-        TimeSpan:    "90 days, 0:00:00",
+        Granularity: gnocchi.Duration(24 * time.Hour),
+        TimeSpan:    gnocchi.Duration(90 * 24 * time.Hour),
       },
     },
   }
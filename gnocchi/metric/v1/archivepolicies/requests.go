@@ -3,6 +3,7 @@ package archivepolicies
 import (
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/pagination"
+	"github.com/gophercloud/utils/gnocchi"
 )
 
 // List makes a request against the Gnocchi API to list archive policies.
@@ -47,7 +48,7 @@ type CreateOpts struct {
 // It configures precision and timespan.
 type ArchivePolicyDefinitionOpts struct {
 	// Granularity is the level of  precision that must be kept when aggregating data.
-	Granularity string `json:"granularity"`
+	Granularity gnocchi.Duration `json:"granularity,omitempty"`
 
 	// Points is a given aggregates or samples in the lifespan of a time series.
 	// Time series is a list of aggregates ordered by time.
@@ -55,7 +56,8 @@ type ArchivePolicyDefinitionOpts struct {
 	Points *int `json:"points,omitempty"`
 
 	// TimeSpan is the time period for which a metric keeps its aggregates.
-	TimeSpan string `json:"timespan"`
+	// It can be omitted if Points are provided.
+	TimeSpan gnocchi.Duration `json:"timespan,omitempty"`
 }
 
 // ToArchivePolicyCreateMap constructs a request body from CreateOpts.
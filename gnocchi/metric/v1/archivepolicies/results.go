@@ -3,6 +3,7 @@ package archivepolicies
 import (
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/pagination"
+	"github.com/gophercloud/utils/gnocchi"
 )
 
 type commonResult struct {
@@ -66,14 +67,14 @@ type ArchivePolicy struct {
 // It configures precision and timespan.
 type ArchivePolicyDefinition struct {
 	// Granularity is the level of  precision that must be kept when aggregating data.
-	Granularity string `json:"granularity"`
+	Granularity gnocchi.Duration `json:"granularity"`
 
 	// Points is a given aggregates or samples in the lifespan of a time series.
 	// Time series is a list of aggregates ordered by time.
 	Points int `json:"points"`
 
 	// TimeSpan is the time period for which a metric keeps its aggregates.
-	TimeSpan string `json:"timespan"`
+	TimeSpan gnocchi.Duration `json:"timespan"`
 }
 
 // ArchivePolicyPage abstracts the raw results of making a List() request against
@@ -0,0 +1,257 @@
+package archivepolicies
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gophercloud/utils/gnocchi"
+)
+
+// EstimatedPointSize is a size in bytes of a single aggregated point in the
+// worst case when Gnocchi can't compress time series. Uncompressed points
+// are stored as a 1-byte flag followed by an 8-byte float value.
+const EstimatedPointSize = 9
+
+// DefaultAggregationMethods is a list of aggregation methods that Gnocchi
+// server uses by default when archive policy doesn't specify them.
+var DefaultAggregationMethods = []string{"mean", "min", "max", "sum", "std", "count"}
+
+// basicAggregationMethods contains aggregation methods that Gnocchi supports
+// in addition to percentiles.
+var basicAggregationMethods = []string{"mean", "sum", "last", "max", "min", "std", "median", "first", "count"}
+
+// RateAggregationPrefix is a prefix of the derived aggregation methods like
+// "rate:mean" that aggregate a rate of change between consecutive measures.
+const RateAggregationPrefix = "rate:"
+
+// StorageEstimate represents an estimated storage cost of a single metric
+// that uses an archive policy.
+type StorageEstimate struct {
+	// Definition is a list of archive policy definitions with all of
+	// granularity, points and timespan calculated.
+	Definition []ArchivePolicyDefinition
+
+	// AggregationMethods is a list of aggregation methods with expanded
+	// "*", "+method" and "-method" entries.
+	AggregationMethods []string
+
+	// Points is a number of aggregated points that a single metric keeps
+	// for all aggregation methods.
+	Points int
+
+	// Bytes is a number of bytes that a single metric takes in the worst case.
+	Bytes int64
+}
+
+// BytesForMetrics returns a number of bytes that the provided number of
+// metrics takes in the worst case.
+func (e StorageEstimate) BytesForMetrics(metrics int) int64 {
+	return e.Bytes * int64(metrics)
+}
+
+// ValidateDefinition checks that archive policy definition and back window
+// are consistent before sending them to the Gnocchi API.
+//
+// Every definition must contain exactly two of Granularity, Points and TimeSpan,
+// TimeSpan must be divisible by Granularity or Points and resulting granularities
+// must be unique. BackWindow can't be negative, Gnocchi doesn't limit it
+// otherwise.
+func ValidateDefinition(definition []ArchivePolicyDefinitionOpts, backWindow int) error {
+	_, err := resolveDefinition(definition, backWindow)
+	return err
+}
+
+// EstimateStorage validates CreateOpts and estimates how many points and bytes
+// a single metric with the provided archive policy will take.
+func EstimateStorage(opts CreateOpts) (*StorageEstimate, error) {
+	definition, err := resolveDefinition(opts.Definition, opts.BackWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	aggregationMethods, err := expandAggregationMethods(opts.AggregationMethods)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := &StorageEstimate{
+		Definition:         definition,
+		AggregationMethods: aggregationMethods,
+	}
+	for _, d := range definition {
+		estimate.Points += d.Points * len(aggregationMethods)
+	}
+	estimate.Bytes = int64(estimate.Points) * EstimatedPointSize
+
+	return estimate, nil
+}
+
+// resolveDefinition validates definition opts and calculates missing values of
+// every definition.
+func resolveDefinition(definition []ArchivePolicyDefinitionOpts, backWindow int) ([]ArchivePolicyDefinition, error) {
+	if len(definition) == 0 {
+		return nil, fmt.Errorf("archive policy definition can't be empty")
+	}
+	if backWindow < 0 {
+		return nil, fmt.Errorf("archive policy back window can't be negative: %d", backWindow)
+	}
+
+	resolved := make([]ArchivePolicyDefinition, len(definition))
+	granularities := make(map[gnocchi.Duration]int, len(definition))
+	for i, opts := range definition {
+		d, err := resolveDefinitionItem(opts)
+		if err != nil {
+			return nil, fmt.Errorf("invalid archive policy definition #%d: %s", i, err)
+		}
+		if j, ok := granularities[d.Granularity]; ok {
+			return nil, fmt.Errorf("archive policy definitions #%d and #%d have the same granularity: %s", j, i, d.Granularity)
+		}
+		granularities[d.Granularity] = i
+		resolved[i] = d
+	}
+
+	return resolved, nil
+}
+
+// resolveDefinitionItem calculates a missing value of a single definition.
+func resolveDefinitionItem(opts ArchivePolicyDefinitionOpts) (ArchivePolicyDefinition, error) {
+	var d ArchivePolicyDefinition
+
+	set := 0
+	if opts.Granularity != 0 {
+		set++
+	}
+	if opts.Points != nil {
+		set++
+	}
+	if opts.TimeSpan != 0 {
+		set++
+	}
+	if set != 2 {
+		return d, fmt.Errorf("exactly two of 'Granularity', 'Points' and 'TimeSpan' should be set")
+	}
+
+	if opts.Granularity < 0 || opts.TimeSpan < 0 {
+		return d, fmt.Errorf("'Granularity' and 'TimeSpan' can't be negative")
+	}
+	if opts.Points != nil && *opts.Points <= 0 {
+		return d, fmt.Errorf("'Points' should be positive, got %d", *opts.Points)
+	}
+
+	switch {
+	case opts.Points == nil:
+		if opts.TimeSpan%opts.Granularity != 0 {
+			return d, fmt.Errorf("'TimeSpan' %s isn't divisible by 'Granularity' %s", opts.TimeSpan, opts.Granularity)
+		}
+		d.Granularity = opts.Granularity
+		d.TimeSpan = opts.TimeSpan
+		d.Points = int(opts.TimeSpan / opts.Granularity)
+	case opts.TimeSpan == 0:
+		d.Granularity = opts.Granularity
+		d.Points = *opts.Points
+		d.TimeSpan = opts.Granularity * gnocchi.Duration(*opts.Points)
+	default:
+		if time.Duration(opts.TimeSpan)%time.Duration(*opts.Points) != 0 {
+			return d, fmt.Errorf("'TimeSpan' %s isn't divisible by %d 'Points'", opts.TimeSpan, *opts.Points)
+		}
+		d.TimeSpan = opts.TimeSpan
+		d.Points = *opts.Points
+		d.Granularity = opts.TimeSpan / gnocchi.Duration(*opts.Points)
+	}
+
+	return d, nil
+}
+
+// expandAggregationMethods returns aggregation methods the same way Gnocchi
+// server does: "*" enables all methods, and entries like "+method" or
+// "-method" modify default aggregation methods if there are only such entries.
+func expandAggregationMethods(aggregationMethods []string) ([]string, error) {
+	if len(aggregationMethods) == 0 {
+		aggregationMethods = DefaultAggregationMethods
+	}
+
+	wildcard := false
+	onlyModifiers := true
+	for _, method := range aggregationMethods {
+		name := method
+		switch {
+		case method == "*":
+			wildcard = true
+			continue
+		case len(method) > 0 && (method[0] == '+' || method[0] == '-'):
+			name = method[1:]
+		default:
+			onlyModifiers = false
+		}
+		if err := ValidateAggregationMethod(name); err != nil {
+			return nil, err
+		}
+	}
+
+	methods := make(map[string]bool)
+	switch {
+	case wildcard:
+		for _, method := range basicAggregationMethods {
+			methods[method] = true
+			methods[RateAggregationPrefix+method] = true
+		}
+		for i := 1; i < 100; i++ {
+			methods[strconv.Itoa(i)+"pct"] = true
+			methods[RateAggregationPrefix+strconv.Itoa(i)+"pct"] = true
+		}
+	case onlyModifiers:
+		for _, method := range DefaultAggregationMethods {
+			methods[method] = true
+		}
+	default:
+		for _, method := range aggregationMethods {
+			if method[0] != '+' && method[0] != '-' {
+				methods[method] = true
+			}
+		}
+	}
+
+	for _, method := range aggregationMethods {
+		switch method[0] {
+		case '+':
+			methods[method[1:]] = true
+		case '-':
+			delete(methods, method[1:])
+		}
+	}
+
+	expanded := make([]string, 0, len(methods))
+	for method := range methods {
+		expanded = append(expanded, method)
+	}
+	sort.Strings(expanded)
+
+	return expanded, nil
+}
+
+// ValidateAggregationMethod checks that the provided name is one of the
+// aggregation methods that Gnocchi accepts in archive policies and measures
+// requests: a basic method, a percentile from "1pct" to "99pct" or one of
+// them with the "rate:" prefix.
+func ValidateAggregationMethod(name string) error {
+	method := strings.TrimPrefix(name, RateAggregationPrefix)
+	for _, m := range basicAggregationMethods {
+		if method == m {
+			return nil
+		}
+	}
+
+	if strings.HasSuffix(method, "pct") {
+		// Gnocchi only accepts integer percentiles without leading zeros.
+		v := strings.TrimSuffix(method, "pct")
+		percentile, err := strconv.Atoi(v)
+		if err == nil && percentile > 0 && percentile < 100 && strconv.Itoa(percentile) == v {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("got an unknown Gnocchi aggregation method: %q", name)
+}
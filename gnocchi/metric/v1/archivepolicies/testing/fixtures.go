@@ -1,6 +1,11 @@
 package testing
 
-import "github.com/gophercloud/utils/gnocchi/metric/v1/archivepolicies"
+import (
+	"time"
+
+	"github.com/gophercloud/utils/gnocchi"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/archivepolicies"
+)
 
 // ArchivePoliciesListResult represents a raw server response from a server to a list call.
 const ArchivePoliciesListResult = `
@@ -63,19 +68,19 @@ var ListArchivePoliciesExpected = []archivepolicies.ArchivePolicy{
 		BackWindow: 0,
 		Definition: []archivepolicies.ArchivePolicyDefinition{
 			{
-				Granularity: "1:00:00",
+				Granularity: gnocchi.Duration(time.Hour),
 				Points:      2304,
-				TimeSpan:    "96 days, 0:00:00",
+				TimeSpan:    gnocchi.Duration(96 * 24 * time.Hour),
 			},
 			{
-				Granularity: "0:05:00",
+				Granularity: gnocchi.Duration(5 * time.Minute),
 				Points:      9216,
-				TimeSpan:    "32 days, 0:00:00",
+				TimeSpan:    gnocchi.Duration(32 * 24 * time.Hour),
 			},
 			{
-				Granularity: "1 day, 0:00:00",
+				Granularity: gnocchi.Duration(24 * time.Hour),
 				Points:      400,
-				TimeSpan:    "400 days, 0:00:00",
+				TimeSpan:    gnocchi.Duration(400 * 24 * time.Hour),
 			},
 		},
 		Name: "precise",
@@ -88,14 +93,14 @@ var ListArchivePoliciesExpected = []archivepolicies.ArchivePolicy{
 		BackWindow: 12,
 		Definition: []archivepolicies.ArchivePolicyDefinition{
 			{
-				Granularity: "1:00:00",
+				Granularity: gnocchi.Duration(time.Hour),
 				Points:      2160,
-				TimeSpan:    "90 days, 0:00:00",
+				TimeSpan:    gnocchi.Duration(90 * 24 * time.Hour),
 			},
 			{
-				Granularity: "1 day, 0:00:00",
+				Granularity: gnocchi.Duration(24 * time.Hour),
 				Points:      200,
-				TimeSpan:    "200 days, 0:00:00",
+				TimeSpan:    gnocchi.Duration(200 * 24 * time.Hour),
 			},
 		},
 		Name: "not_so_precise",
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/gophercloud/gophercloud/pagination"
 	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/utils/gnocchi"
 	"github.com/gophercloud/utils/gnocchi/metric/v1/archivepolicies"
 	fake "github.com/gophercloud/utils/gnocchi/testhelper/client"
 )
@@ -89,14 +91,14 @@ func TestGetArchivePolicy(t *testing.T) {
 	th.AssertEquals(t, s.BackWindow, 128)
 	th.AssertDeepEquals(t, s.Definition, []archivepolicies.ArchivePolicyDefinition{
 		{
-			Granularity: "1:00:00",
+			Granularity: gnocchi.Duration(time.Hour),
 			Points:      2160,
-			TimeSpan:    "90 days, 0:00:00",
+			TimeSpan:    gnocchi.Duration(90 * 24 * time.Hour),
 		},
 		{
-			Granularity: "1 day, 0:00:00",
+			Granularity: gnocchi.Duration(24 * time.Hour),
 			Points:      100,
-			TimeSpan:    "100 days, 0:00:00",
+			TimeSpan:    gnocchi.Duration(100 * 24 * time.Hour),
 		},
 	})
 	th.AssertEquals(t, s.Name, "test_policy")
@@ -128,12 +130,12 @@ func TestCreate(t *testing.T) {
 		},
 		Definition: []archivepolicies.ArchivePolicyDefinitionOpts{
 			{
-				Granularity: "1:00:00",
-				TimeSpan:    "90 days, 0:00:00",
+				Granularity: gnocchi.Duration(time.Hour),
+				TimeSpan:    gnocchi.Duration(90 * 24 * time.Hour),
 			},
 			{
-				Granularity: "1 day, 0:00:00",
-				TimeSpan:    "100 days, 0:00:00",
+				Granularity: gnocchi.Duration(24 * time.Hour),
+				TimeSpan:    gnocchi.Duration(100 * 24 * time.Hour),
 			},
 		},
 		Name: "test_policy",
@@ -149,14 +151,14 @@ func TestCreate(t *testing.T) {
 	th.AssertEquals(t, s.BackWindow, 31)
 	th.AssertDeepEquals(t, s.Definition, []archivepolicies.ArchivePolicyDefinition{
 		{
-			Granularity: "1:00:00",
+			Granularity: gnocchi.Duration(time.Hour),
 			Points:      2160,
-			TimeSpan:    "90 days, 0:00:00",
+			TimeSpan:    gnocchi.Duration(90 * 24 * time.Hour),
 		},
 		{
-			Granularity: "1 day, 0:00:00",
+			Granularity: gnocchi.Duration(24 * time.Hour),
 			Points:      100,
-			TimeSpan:    "100 days, 0:00:00",
+			TimeSpan:    gnocchi.Duration(100 * 24 * time.Hour),
 		},
 	})
 	th.AssertEquals(t, s.Name, "test_policy")
@@ -182,12 +184,12 @@ func TestUpdateArchivePolicy(t *testing.T) {
 	updateOpts := archivepolicies.UpdateOpts{
 		Definition: []archivepolicies.ArchivePolicyDefinitionOpts{
 			{
-				Granularity: "12:00:00",
-				TimeSpan:    "30 days, 0:00:00",
+				Granularity: gnocchi.Duration(12 * time.Hour),
+				TimeSpan:    gnocchi.Duration(30 * 24 * time.Hour),
 			},
 			{
-				Granularity: "1 day, 0:00:00",
-				TimeSpan:    "90 days, 0:00:00",
+				Granularity: gnocchi.Duration(24 * time.Hour),
+				TimeSpan:    gnocchi.Duration(90 * 24 * time.Hour),
 			},
 		},
 	}
@@ -200,14 +202,14 @@ func TestUpdateArchivePolicy(t *testing.T) {
 	th.AssertEquals(t, s.BackWindow, 0)
 	th.AssertDeepEquals(t, s.Definition, []archivepolicies.ArchivePolicyDefinition{
 		{
-			Granularity: "12:00:00",
+			Granularity: gnocchi.Duration(12 * time.Hour),
 			Points:      60,
-			TimeSpan:    "30 days, 0:00:00",
+			TimeSpan:    gnocchi.Duration(30 * 24 * time.Hour),
 		},
 		{
-			Granularity: "1 day, 0:00:00",
+			Granularity: gnocchi.Duration(24 * time.Hour),
 			Points:      90,
-			TimeSpan:    "90 days, 0:00:00",
+			TimeSpan:    gnocchi.Duration(90 * 24 * time.Hour),
 		},
 	})
 	th.AssertEquals(t, s.Name, "test_policy")
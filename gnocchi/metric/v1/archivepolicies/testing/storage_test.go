@@ -0,0 +1,202 @@
+package testing
+
+import (
+	"testing"
+	"time"
+
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/utils/gnocchi"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/archivepolicies"
+)
+
+func TestEstimateStorage(t *testing.T) {
+	points := 720
+	opts := archivepolicies.CreateOpts{
+		Name:               "test_policy",
+		AggregationMethods: []string{"mean", "max", "95pct"},
+		BackWindow:         1,
+		Definition: []archivepolicies.ArchivePolicyDefinitionOpts{
+			{
+				Granularity: gnocchi.Duration(5 * time.Minute),
+				TimeSpan:    gnocchi.Duration(24 * time.Hour),
+			},
+			{
+				Granularity: gnocchi.Duration(time.Hour),
+				Points:      &points,
+			},
+			{
+				Points:   &points,
+				TimeSpan: gnocchi.Duration(360 * 24 * time.Hour),
+			},
+		},
+	}
+
+	actual, err := archivepolicies.EstimateStorage(opts)
+	th.AssertNoErr(t, err)
+
+	expected := &archivepolicies.StorageEstimate{
+		Definition: []archivepolicies.ArchivePolicyDefinition{
+			{
+				Granularity: gnocchi.Duration(5 * time.Minute),
+				Points:      288,
+				TimeSpan:    gnocchi.Duration(24 * time.Hour),
+			},
+			{
+				Granularity: gnocchi.Duration(time.Hour),
+				Points:      720,
+				TimeSpan:    gnocchi.Duration(30 * 24 * time.Hour),
+			},
+			{
+				Granularity: gnocchi.Duration(12 * time.Hour),
+				Points:      720,
+				TimeSpan:    gnocchi.Duration(360 * 24 * time.Hour),
+			},
+		},
+		AggregationMethods: []string{"95pct", "max", "mean"},
+		Points:             5184,
+		Bytes:              46656,
+	}
+	th.CheckDeepEquals(t, expected, actual)
+	th.CheckEquals(t, int64(46656000), actual.BytesForMetrics(1000))
+}
+
+func TestEstimateStorageAggregationMethods(t *testing.T) {
+	definition := []archivepolicies.ArchivePolicyDefinitionOpts{
+		{
+			Granularity: gnocchi.Duration(time.Hour),
+			TimeSpan:    gnocchi.Duration(time.Hour),
+		},
+	}
+
+	methods := map[string]struct {
+		aggregationMethods []string
+		expected           int
+	}{
+		"default":   {nil, 6},
+		"modifiers": {[]string{"-std", "-count", "+last"}, 5},
+		"wildcard":  {[]string{"*", "-count"}, 215},
+		"rate":      {[]string{"mean", "95pct", "rate:mean"}, 3},
+		"explicit":  {[]string{"mean", "+last", "-mean"}, 1},
+	}
+
+	for name, m := range methods {
+		actual, err := archivepolicies.EstimateStorage(archivepolicies.CreateOpts{
+			AggregationMethods: m.aggregationMethods,
+			Definition:         definition,
+		})
+		th.AssertNoErr(t, err)
+		if len(actual.AggregationMethods) != m.expected {
+			t.Errorf("Expected %d %s aggregation methods, got %v", m.expected, name, actual.AggregationMethods)
+		}
+		th.CheckEquals(t, m.expected, actual.Points)
+	}
+
+	_, err := archivepolicies.EstimateStorage(archivepolicies.CreateOpts{
+		AggregationMethods: []string{"average"},
+		Definition:         definition,
+	})
+	if err == nil {
+		t.Fatal("Expected an error for an unknown aggregation method")
+	}
+}
+
+func TestValidateDefinition(t *testing.T) {
+	points := 24
+	zero := 0
+
+	th.AssertNoErr(t, archivepolicies.ValidateDefinition([]archivepolicies.ArchivePolicyDefinitionOpts{
+		{
+			Granularity: gnocchi.Duration(time.Hour),
+			Points:      &points,
+		},
+	}, 23))
+
+	// The back window may exceed the number of points of the coarsest
+	// granularity.
+	th.AssertNoErr(t, archivepolicies.ValidateDefinition([]archivepolicies.ArchivePolicyDefinitionOpts{
+		{
+			Granularity: gnocchi.Duration(time.Minute),
+			TimeSpan:    gnocchi.Duration(24 * time.Hour),
+		},
+		{
+			Granularity: gnocchi.Duration(time.Hour),
+			Points:      &points,
+		},
+	}, 48))
+
+	invalid := map[string]struct {
+		definition []archivepolicies.ArchivePolicyDefinitionOpts
+		backWindow int
+	}{
+		"empty definition": {},
+		"single value": {
+			definition: []archivepolicies.ArchivePolicyDefinitionOpts{
+				{Granularity: gnocchi.Duration(time.Hour)},
+			},
+		},
+		"all values": {
+			definition: []archivepolicies.ArchivePolicyDefinitionOpts{
+				{
+					Granularity: gnocchi.Duration(time.Hour),
+					Points:      &points,
+					TimeSpan:    gnocchi.Duration(24 * time.Hour),
+				},
+			},
+		},
+		"indivisible timespan": {
+			definition: []archivepolicies.ArchivePolicyDefinitionOpts{
+				{
+					Granularity: gnocchi.Duration(7 * time.Minute),
+					TimeSpan:    gnocchi.Duration(time.Hour),
+				},
+			},
+		},
+		"zero points": {
+			definition: []archivepolicies.ArchivePolicyDefinitionOpts{
+				{
+					Granularity: gnocchi.Duration(time.Hour),
+					Points:      &zero,
+				},
+			},
+		},
+		"duplicate granularities": {
+			definition: []archivepolicies.ArchivePolicyDefinitionOpts{
+				{
+					Granularity: gnocchi.Duration(time.Hour),
+					Points:      &points,
+				},
+				{
+					Points:   &points,
+					TimeSpan: gnocchi.Duration(24 * time.Hour),
+				},
+			},
+		},
+		"negative back window": {
+			definition: []archivepolicies.ArchivePolicyDefinitionOpts{
+				{
+					Granularity: gnocchi.Duration(time.Hour),
+					Points:      &points,
+				},
+			},
+			backWindow: -1,
+		},
+	}
+
+	for name, opts := range invalid {
+		if err := archivepolicies.ValidateDefinition(opts.definition, opts.backWindow); err == nil {
+			t.Errorf("Expected an error for the %s case", name)
+		}
+	}
+}
+
+func TestValidateAggregationMethod(t *testing.T) {
+	for _, method := range []string{"mean", "count", "1pct", "99pct", "rate:mean", "rate:95pct"} {
+		th.AssertNoErr(t, archivepolicies.ValidateAggregationMethod(method))
+	}
+
+	for _, method := range []string{"", "average", "0pct", "100pct", "99.9pct", "05pct", "rate:", "rate:average", "rate:rate:mean"} {
+		if err := archivepolicies.ValidateAggregationMethod(method); err == nil {
+			t.Errorf("Expected an error for the %q aggregation method", method)
+		}
+	}
+}
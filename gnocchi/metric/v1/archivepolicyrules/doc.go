@@ -0,0 +1,58 @@
+/*
+Package archivepolicyrules provides the ability to manage archive policy rules
+through the Gnocchi API.
+
+Example of Listing archive policy rules
+
+	allPages, err := archivepolicyrules.List(gnocchiClient).AllPages()
+	if err != nil {
+		panic(err)
+	}
+
+	allArchivePolicyRules, err := archivepolicyrules.ExtractArchivePolicyRules(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, archivePolicyRule := range allArchivePolicyRules {
+		fmt.Printf("%+v\n", archivePolicyRule)
+	}
+
+Example of Getting an archive policy rule
+
+	archivePolicyRuleName := "my_rule"
+	archivePolicyRule, err := archivepolicyrules.Get(gnocchiClient, archivePolicyRuleName).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example of Creating an archive policy rule
+
+	createOpts := archivepolicyrules.CreateOpts{
+		ArchivePolicyName: "high",
+		MetricPattern:     "disk.io.*",
+		Name:              "disk_io_rule",
+	}
+	archivePolicyRule, err := archivepolicyrules.Create(gnocchiClient, createOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example of Renaming an archive policy rule
+
+	updateOpts := archivepolicyrules.UpdateOpts{
+		Name: "new_disk_io_rule",
+	}
+	archivePolicyRule, err := archivepolicyrules.Update(gnocchiClient, "disk_io_rule", updateOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example of Deleting an archive policy rule
+
+	err := archivepolicyrules.Delete(gnocchiClient, "new_disk_io_rule").ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+*/
+package archivepolicyrules
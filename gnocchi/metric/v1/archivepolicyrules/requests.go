@@ -0,0 +1,98 @@
+package archivepolicyrules
+
+import (
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+// List makes a request against the Gnocchi API to list archive policy rules.
+func List(client *gophercloud.ServiceClient) pagination.Pager {
+	return pagination.NewPager(client, listURL(client), func(r pagination.PageResult) pagination.Page {
+		return ArchivePolicyRulePage{pagination.SinglePageBase(r)}
+	})
+}
+
+// Get retrieves a specific Gnocchi archive policy rule based on its name.
+func Get(c *gophercloud.ServiceClient, archivePolicyRuleName string) (r GetResult) {
+	_, r.Err = c.Get(getURL(c, archivePolicyRuleName), &r.Body, nil)
+	return
+}
+
+// CreateOptsBuilder allows extensions to add additional parameters to the Create request.
+type CreateOptsBuilder interface {
+	ToArchivePolicyRuleCreateMap() (map[string]interface{}, error)
+}
+
+// CreateOpts specifies parameters of a new archive policy rule.
+type CreateOpts struct {
+	// ArchivePolicyName is a name of the Gnocchi archive policy that will be
+	// assigned to metrics matching the MetricPattern.
+	ArchivePolicyName string `json:"archive_policy_name" required:"true"`
+
+	// MetricPattern is a wildcard pattern of metric names, for example "disk.io.*".
+	MetricPattern string `json:"metric_pattern" required:"true"`
+
+	// Name is a name of an archive policy rule.
+	Name string `json:"name" required:"true"`
+}
+
+// ToArchivePolicyRuleCreateMap constructs a request body from CreateOpts.
+func (opts CreateOpts) ToArchivePolicyRuleCreateMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "")
+}
+
+// Create requests the creation of a new Gnocchi archive policy rule on the server.
+func Create(client *gophercloud.ServiceClient, opts CreateOptsBuilder) (r CreateResult) {
+	b, err := opts.ToArchivePolicyRuleCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Post(createURL(client), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{201},
+	})
+
+	return
+}
+
+// UpdateOptsBuilder allows extensions to add additional parameters to the Update request.
+type UpdateOptsBuilder interface {
+	ToArchivePolicyRuleUpdateMap() (map[string]interface{}, error)
+}
+
+// UpdateOpts represents options used to update an archive policy rule.
+// Gnocchi only allows to rename an archive policy rule.
+type UpdateOpts struct {
+	// Name is a new name of an archive policy rule.
+	Name string `json:"name" required:"true"`
+}
+
+// ToArchivePolicyRuleUpdateMap constructs a request body from UpdateOpts.
+func (opts UpdateOpts) ToArchivePolicyRuleUpdateMap() (map[string]interface{}, error) {
+	return gophercloud.BuildRequestBody(opts, "")
+}
+
+// Update accepts a UpdateOpts and updates an existing Gnocchi archive policy rule using the values provided.
+func Update(client *gophercloud.ServiceClient, archivePolicyRuleName string, opts UpdateOptsBuilder) (r UpdateResult) {
+	b, err := opts.ToArchivePolicyRuleUpdateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Patch(updateURL(client, archivePolicyRuleName), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+
+	return
+}
+
+// Delete accepts a Gnocchi archive policy rule by its name.
+func Delete(c *gophercloud.ServiceClient, archivePolicyRuleName string) (r DeleteResult) {
+	requestOpts := &gophercloud.RequestOpts{
+		MoreHeaders: map[string]string{
+			"Accept": "application/json, */*",
+		},
+	}
+	_, r.Err = c.Delete(deleteURL(c, archivePolicyRuleName), requestOpts)
+	return
+}
@@ -0,0 +1,84 @@
+package archivepolicyrules
+
+import (
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/pagination"
+)
+
+type commonResult struct {
+	gophercloud.Result
+}
+
+// Extract is a function that accepts a result and extracts a Gnocchi archive policy rule.
+func (r commonResult) Extract() (*ArchivePolicyRule, error) {
+	var s *ArchivePolicyRule
+	err := r.ExtractInto(&s)
+	return s, err
+}
+
+// GetResult represents the result of a get operation. Call its Extract
+// method to interpret it as an ArchivePolicyRule.
+type GetResult struct {
+	commonResult
+}
+
+// CreateResult represents the result of a create operation. Call its Extract
+// method to interpret it as a Gnocchi archive policy rule.
+type CreateResult struct {
+	commonResult
+}
+
+// UpdateResult represents the result of an update operation. Call its Extract
+// method to interpret it as a Gnocchi archive policy rule.
+type UpdateResult struct {
+	commonResult
+}
+
+// DeleteResult represents the result of a delete operation. Call its
+// ExtractErr method to determine if the request succeeded or failed.
+type DeleteResult struct {
+	gophercloud.ErrResult
+}
+
+// ArchivePolicyRule represents a Gnocchi archive policy rule.
+// Archive policy rule is used to assign an archive policy to a new metric
+// automatically if the metric's name matches the rule's pattern.
+type ArchivePolicyRule struct {
+	// ArchivePolicyName is a name of the Gnocchi archive policy that is
+	// assigned to metrics matching the MetricPattern.
+	ArchivePolicyName string `json:"archive_policy_name"`
+
+	// MetricPattern is a wildcard pattern of metric names.
+	MetricPattern string `json:"metric_pattern"`
+
+	// Name is a name of an archive policy rule.
+	Name string `json:"name"`
+}
+
+// ArchivePolicyRulePage abstracts the raw results of making a List() request against
+// the Gnocchi API.
+//
+// As Gnocchi API may freely alter the response bodies of structures
+// returned to the client, you may only safely access the data provided through
+// the ExtractArchivePolicyRules call.
+type ArchivePolicyRulePage struct {
+	pagination.SinglePageBase
+}
+
+// IsEmpty returns true if an ArchivePolicyRulePage contains no archive policy rules.
+func (r ArchivePolicyRulePage) IsEmpty() (bool, error) {
+	archivePolicyRules, err := ExtractArchivePolicyRules(r)
+	return len(archivePolicyRules) == 0, err
+}
+
+// ExtractArchivePolicyRules interprets the results of a single page from a List() call,
+// producing a slice of ArchivePolicyRule structs.
+func ExtractArchivePolicyRules(r pagination.Page) ([]ArchivePolicyRule, error) {
+	var s []ArchivePolicyRule
+	err := (r.(ArchivePolicyRulePage)).ExtractInto(&s)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, err
+}
@@ -0,0 +1,2 @@
+// archivepolicyrules unit tests
+package testing
@@ -0,0 +1,76 @@
+package testing
+
+import "github.com/gophercloud/utils/gnocchi/metric/v1/archivepolicyrules"
+
+// ArchivePolicyRulesListResult represents a raw server response from a server to a list call.
+const ArchivePolicyRulesListResult = `
+[
+    {
+        "archive_policy_name": "high",
+        "metric_pattern": "disk.io.*",
+        "name": "disk_io_rule"
+    },
+    {
+        "archive_policy_name": "low",
+        "metric_pattern": "*",
+        "name": "default"
+    }
+]
+`
+
+// ListArchivePolicyRulesExpected represents an expected repsonse from a List request.
+var ListArchivePolicyRulesExpected = []archivepolicyrules.ArchivePolicyRule{
+	{
+		ArchivePolicyName: "high",
+		MetricPattern:     "disk.io.*",
+		Name:              "disk_io_rule",
+	},
+	{
+		ArchivePolicyName: "low",
+		MetricPattern:     "*",
+		Name:              "default",
+	},
+}
+
+// ArchivePolicyRuleGetResult represents a raw server response from a server to a get request.
+const ArchivePolicyRuleGetResult = `
+{
+    "archive_policy_name": "high",
+    "metric_pattern": "disk.io.*",
+    "name": "disk_io_rule"
+}
+`
+
+// ArchivePolicyRuleCreateRequest represents a raw create request.
+const ArchivePolicyRuleCreateRequest = `
+{
+    "archive_policy_name": "medium",
+    "metric_pattern": "network.*",
+    "name": "network_rule"
+}
+`
+
+// ArchivePolicyRuleCreateResponse represents a raw server response from a server to a create request.
+const ArchivePolicyRuleCreateResponse = `
+{
+    "archive_policy_name": "medium",
+    "metric_pattern": "network.*",
+    "name": "network_rule"
+}
+`
+
+// ArchivePolicyRuleUpdateRequest represents a raw update request.
+const ArchivePolicyRuleUpdateRequest = `
+{
+    "name": "new_network_rule"
+}
+`
+
+// ArchivePolicyRuleUpdateResponse represents a raw server response from a server to an update request.
+const ArchivePolicyRuleUpdateResponse = `
+{
+    "archive_policy_name": "medium",
+    "metric_pattern": "network.*",
+    "name": "new_network_rule"
+}
+`
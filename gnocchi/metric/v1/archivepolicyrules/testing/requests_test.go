@@ -0,0 +1,139 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/gophercloud/gophercloud/pagination"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/archivepolicyrules"
+	fake "github.com/gophercloud/utils/gnocchi/testhelper/client"
+)
+
+func TestListArchivePolicyRules(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/archive_policy_rule", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprintf(w, ArchivePolicyRulesListResult)
+	})
+
+	expected := ListArchivePolicyRulesExpected
+	pages := 0
+	err := archivepolicyrules.List(fake.ServiceClient()).EachPage(func(page pagination.Page) (bool, error) {
+		pages++
+
+		actual, err := archivepolicyrules.ExtractArchivePolicyRules(page)
+		th.AssertNoErr(t, err)
+
+		if len(actual) != 2 {
+			t.Fatalf("Expected 2 archive policy rules, got %d", len(actual))
+		}
+		th.CheckDeepEquals(t, expected, actual)
+
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, 1, pages)
+}
+
+func TestGetArchivePolicyRule(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/archive_policy_rule/disk_io_rule", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprintf(w, ArchivePolicyRuleGetResult)
+	})
+
+	s, err := archivepolicyrules.Get(fake.ServiceClient(), "disk_io_rule").Extract()
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, s.ArchivePolicyName, "high")
+	th.AssertEquals(t, s.MetricPattern, "disk.io.*")
+	th.AssertEquals(t, s.Name, "disk_io_rule")
+}
+
+func TestCreateArchivePolicyRule(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/archive_policy_rule", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, ArchivePolicyRuleCreateRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+
+		fmt.Fprintf(w, ArchivePolicyRuleCreateResponse)
+	})
+
+	opts := archivepolicyrules.CreateOpts{
+		ArchivePolicyName: "medium",
+		MetricPattern:     "network.*",
+		Name:              "network_rule",
+	}
+	s, err := archivepolicyrules.Create(fake.ServiceClient(), opts).Extract()
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, s.ArchivePolicyName, "medium")
+	th.AssertEquals(t, s.MetricPattern, "network.*")
+	th.AssertEquals(t, s.Name, "network_rule")
+}
+
+func TestUpdateArchivePolicyRule(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/archive_policy_rule/network_rule", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PATCH")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, ArchivePolicyRuleUpdateRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprintf(w, ArchivePolicyRuleUpdateResponse)
+	})
+
+	updateOpts := archivepolicyrules.UpdateOpts{
+		Name: "new_network_rule",
+	}
+	s, err := archivepolicyrules.Update(fake.ServiceClient(), "network_rule", updateOpts).Extract()
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, s.ArchivePolicyName, "medium")
+	th.AssertEquals(t, s.MetricPattern, "network.*")
+	th.AssertEquals(t, s.Name, "new_network_rule")
+}
+
+func TestDeleteArchivePolicyRule(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/archive_policy_rule/network_rule", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	res := archivepolicyrules.Delete(fake.ServiceClient(), "network_rule")
+	th.AssertNoErr(t, res.Err)
+}
@@ -0,0 +1,33 @@
+package archivepolicyrules
+
+import "github.com/gophercloud/gophercloud"
+
+const resourcePath = "archive_policy_rule"
+
+func resourceURL(c *gophercloud.ServiceClient, archivePolicyRuleName string) string {
+	return c.ServiceURL(resourcePath, archivePolicyRuleName)
+}
+
+func rootURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL(resourcePath)
+}
+
+func listURL(c *gophercloud.ServiceClient) string {
+	return rootURL(c)
+}
+
+func getURL(c *gophercloud.ServiceClient, archivePolicyRuleName string) string {
+	return resourceURL(c, archivePolicyRuleName)
+}
+
+func createURL(c *gophercloud.ServiceClient) string {
+	return rootURL(c)
+}
+
+func updateURL(c *gophercloud.ServiceClient, archivePolicyRuleName string) string {
+	return resourceURL(c, archivePolicyRuleName)
+}
+
+func deleteURL(c *gophercloud.ServiceClient, archivePolicyRuleName string) string {
+	return resourceURL(c, archivePolicyRuleName)
+}
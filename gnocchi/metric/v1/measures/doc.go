@@ -6,8 +6,8 @@ Example of Listing measures of a known metric
 	startTime := time.Date(2018, 1, 4, 10, 0, 0, 0, time.UTC)
 	metricID := "9e5a6441-1044-4181-b66e-34e180753040"
 	listOpts := measures.ListOpts{
-		Resample: "2h",
-		Granularity: "1h",
+		Resample: gnocchi.Duration(2 * time.Hour),
+		Granularity: gnocchi.Duration(time.Hour),
 		Start: &startTime,
 	}
 	allPages, err := measures.List(gnocchiClient, metricID, listOpts).AllPages()
@@ -24,6 +24,71 @@ Example of Listing measures of a known metric
 		fmt.Printf("%+v\n", measure)
 	}
 
+Example of Listing measures of a metric referenced by its resource and name
+
+	resourceType := "compute_instance"
+	resourceID := "1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc"
+	metricName := "cpu_util"
+	listOpts := measures.ListOpts{
+		Granularity: gnocchi.Duration(time.Hour),
+	}
+	allPages, err := measures.ListByResource(gnocchiClient, resourceType, resourceID, metricName, listOpts).AllPages()
+	if err != nil {
+		panic(err)
+	}
+
+	allMeasures, err := measures.ExtractMeasures(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+Example of Listing rates of a known metric with transformations
+
+	metric, err := metrics.Get(gnocchiClient, "9e5a6441-1044-4181-b66e-34e180753040").Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	if err := measures.ValidateAggregation("rate:mean", metric.ArchivePolicy); err != nil {
+		panic(err)
+	}
+
+	listOpts := measures.ListOpts{
+		Aggregation: "rate:mean",
+		Fill:        "null",
+		Transform: []measures.Transform{
+			measures.TransformAbsolute,
+			measures.TransformResample("mean", gnocchi.Duration(time.Hour)),
+		},
+	}
+	allPages, err := measures.List(gnocchiClient, metric.ID, listOpts).AllPages()
+	if err != nil {
+		panic(err)
+	}
+
+Example of Streaming measures of a known metric by daily chunks
+
+	startTime := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	stopTime := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	metricID := "9e5a6441-1044-4181-b66e-34e180753040"
+	streamOpts := measures.StreamOpts{
+		ListOpts: measures.ListOpts{
+			Granularity: gnocchi.Duration(time.Minute),
+			Start:       &startTime,
+			Stop:        &stopTime,
+		},
+		ChunkSize: 24 * time.Hour,
+	}
+	iter := measures.Stream(gnocchiClient, metricID, streamOpts)
+	defer iter.Close()
+
+	for iter.Next() {
+		fmt.Printf("%+v\n", iter.Measure())
+	}
+	if err := iter.Err(); err != nil {
+		panic(err)
+	}
+
 Example of Creating measures inside a single metric
 
 	createOpts := measures.CreateOpts{
@@ -43,6 +108,24 @@ Example of Creating measures inside a single metric
 		panic(err)
 	}
 
+Example of Creating measures inside a single metric referenced by its resource and name
+
+	currentTimestamp := time.Now().UTC()
+	createOpts := measures.CreateOpts{
+		Measures: []measures.MeasureOpts{
+			{
+				Timestamp: &currentTimestamp,
+				Value:     42.5,
+			},
+		},
+	}
+	resourceType := "compute_instance"
+	resourceID := "1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc"
+	metricName := "cpu_util"
+	if err := measures.CreateByResource(gnocchiClient, resourceType, resourceID, metricName, createOpts).ExtractErr(); err != nil {
+		panic(err)
+	}
+
 Example of Creating measures inside different metrics via metric ID references in a single request
 
 	currentTimestamp := time.Now().UTC()
@@ -146,5 +229,36 @@ Example of Creating measures inside different metrics via metric names and resou
 	if err := measures.BatchCreateResourcesMetrics(gnocchiClient, createOpts).ExtractErr(); err != nil {
 		panic(err)
 	}
+
+Example of Writing measures with a buffered Writer
+
+	writerOpts := measures.WriterOpts{
+		BatchSize:     500,
+		FlushInterval: 5 * time.Second,
+		Workers:       2,
+		MaxRetries:    3,
+		ErrorHandler: func(err error) {
+			log.Printf("failed to write measures: %s", err)
+		},
+	}
+	writer, err := measures.NewWriter(gnocchiClient, writerOpts)
+	if err != nil {
+		panic(err)
+	}
+
+	currentTimestamp := time.Now().UTC()
+	measureOpts := measures.MeasureOpts{
+		Timestamp: &currentTimestamp,
+		Value:     42.5,
+	}
+	if err := writer.WriteMetric("777a01d6-4694-49cb-b86a-5ba9fd4e609e", measureOpts); err != nil {
+		panic(err)
+	}
+
+	if err := writer.Close(); err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("%+v\n", writer.Stats())
 */
 package measures
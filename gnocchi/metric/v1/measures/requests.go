@@ -2,12 +2,15 @@ package measures
 
 import (
 	"fmt"
+	"io"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/pagination"
 	"github.com/gophercloud/utils/gnocchi"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/archivepolicies"
 )
 
 // ListOptsBuilder allows extensions to add additional parameters to the
@@ -34,15 +37,33 @@ type ListOpts struct {
 
 	// Granularity is a needed time between two series of measures to retreive.
 	// Gnocchi will response with all granularities for available measures by default.
-	Granularity string `q:"granularity"`
+	Granularity gnocchi.Duration
 
 	// Resample allows to select different granularity instead of those that were defined in the
 	// archive policy.
-	Resample string `q:"resample"`
+	Resample gnocchi.Duration
+
+	// Fill is a value that is used to fill missing points of the measures.
+	// It can be a number, "null" or "ffill". Filled missing points have the
+	// NaN value in case of "null".
+	Fill string `q:"fill"`
+
+	// Limit allows to limit count of measures in the response.
+	Limit int `q:"limit"`
+
+	// Transform is a list of transformations that Gnocchi applies to the
+	// measures in the provided order.
+	Transform []Transform
 }
 
 // ToMeasureListQuery formats a ListOpts into a query string.
 func (opts ListOpts) ToMeasureListQuery() (string, error) {
+	if opts.Aggregation != "" {
+		if err := archivepolicies.ValidateAggregationMethod(opts.Aggregation); err != nil {
+			return "", err
+		}
+	}
+
 	q, err := gophercloud.BuildQueryString(opts)
 	params := q.Query()
 
@@ -54,10 +75,65 @@ func (opts ListOpts) ToMeasureListQuery() (string, error) {
 		params.Add("stop", opts.Stop.Format(gnocchi.RFC3339NanoNoTimezone))
 	}
 
+	if opts.Granularity != 0 {
+		params.Add("granularity", opts.Granularity.QueryString())
+	}
+
+	if opts.Resample != 0 {
+		params.Add("resample", opts.Resample.QueryString())
+	}
+
+	if len(opts.Transform) > 0 {
+		transforms := make([]string, len(opts.Transform))
+		for i, transform := range opts.Transform {
+			if transform == "" {
+				return "", fmt.Errorf("got an empty transformation in the ListOpts 'Transform' argument")
+			}
+			transforms[i] = string(transform)
+		}
+		params.Add("transform", strings.Join(transforms, ":"))
+	}
+
 	q = &url.URL{RawQuery: params.Encode()}
 	return q.String(), err
 }
 
+// Transform represents a Gnocchi transformation of measures.
+type Transform string
+
+const (
+	// TransformAbsolute replaces values of measures with their absolute values.
+	TransformAbsolute Transform = "absolute"
+
+	// TransformNegative replaces values of measures with their negated values.
+	TransformNegative Transform = "negative"
+)
+
+// TransformResample builds a Transform that aggregates measures into a new
+// granularity with the provided aggregation method.
+func TransformResample(aggregation string, granularity gnocchi.Duration) Transform {
+	return Transform(fmt.Sprintf("resample(%s,%s)", aggregation, granularity.QueryString()))
+}
+
+// ValidateAggregation checks that measures aggregated with the provided method
+// can be read from a Gnocchi metric with the provided archive policy.
+// Gnocchi computes only those aggregations that are listed in the archive
+// policy, including the derived "rate:*" aggregations like "rate:mean".
+func ValidateAggregation(aggregation string, archivePolicy archivepolicies.ArchivePolicy) error {
+	if err := archivepolicies.ValidateAggregationMethod(aggregation); err != nil {
+		return err
+	}
+
+	for _, method := range archivePolicy.AggregationMethods {
+		if method == aggregation {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("aggregation method %q isn't enabled in the %q archive policy, available methods: %s",
+		aggregation, archivePolicy.Name, strings.Join(archivePolicy.AggregationMethods, ", "))
+}
+
 // List returns a Pager which allows you to iterate over a collection of
 // measures.
 // It accepts a ListOpts struct, which allows you to provide options to a Gnocchi measures List request.
@@ -75,6 +151,112 @@ func List(c *gophercloud.ServiceClient, metricID string, opts ListOptsBuilder) p
 	})
 }
 
+// ListByResource returns a Pager which allows you to iterate over a collection
+// of measures of a metric that is referenced by its resource and name.
+// It accepts a ListOpts struct, which allows you to provide options to a Gnocchi measures List request.
+func ListByResource(c *gophercloud.ServiceClient, resourceType, resourceID, metricName string, opts ListOptsBuilder) pagination.Pager {
+	url := listByResourceURL(c, resourceType, resourceID, metricName)
+	if opts != nil {
+		query, err := opts.ToMeasureListQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(c, url, func(r pagination.PageResult) pagination.Page {
+		return MeasurePage{pagination.SinglePageBase(r)}
+	})
+}
+
+// StreamOpts allows to provide options to the Gnocchi measures Stream request.
+type StreamOpts struct {
+	// ListOpts contains options of every Gnocchi measures List request that
+	// is used to read measures.
+	ListOpts
+
+	// ChunkSize allows to split a long time range between ListOpts.Start and
+	// ListOpts.Stop into several consecutive requests that cover no more than
+	// ChunkSize each. All measures are read with a single request if it's zero.
+	ChunkSize time.Duration
+}
+
+// chunks splits StreamOpts into ListOpts of consecutive requests.
+func (opts StreamOpts) chunks() ([]ListOpts, error) {
+	if opts.ChunkSize == 0 {
+		return []ListOpts{opts.ListOpts}, nil
+	}
+	if opts.ChunkSize < 0 {
+		return nil, fmt.Errorf("got an invalid StreamOpts 'ChunkSize' argument: %s", opts.ChunkSize)
+	}
+	if opts.Start == nil || opts.Stop == nil {
+		return nil, fmt.Errorf("'Start' and 'Stop' arguments are required to split measures into chunks")
+	}
+	if !opts.Start.Before(*opts.Stop) {
+		return nil, fmt.Errorf("'Start' argument should be before the 'Stop' argument")
+	}
+
+	var chunks []ListOpts
+	for start := *opts.Start; start.Before(*opts.Stop); start = start.Add(opts.ChunkSize) {
+		chunkStart := start
+		chunkStop := start.Add(opts.ChunkSize)
+		if chunkStop.After(*opts.Stop) {
+			chunkStop = *opts.Stop
+		}
+
+		chunk := opts.ListOpts
+		chunk.Start = &chunkStart
+		chunk.Stop = &chunkStop
+		chunks = append(chunks, chunk)
+	}
+
+	return chunks, nil
+}
+
+// Stream returns a MeasureIterator which allows you to read measures of the
+// Gnocchi metric one at a time without loading the whole response into memory.
+// It accepts a StreamOpts struct, which allows you to split a long time range
+// into several requests.
+func Stream(c *gophercloud.ServiceClient, metricID string, opts StreamOpts) *MeasureIterator {
+	return stream(c, listURL(c, metricID), opts)
+}
+
+// StreamByResource returns a MeasureIterator which allows you to read measures
+// of a metric that is referenced by its resource and name one at a time.
+// It accepts a StreamOpts struct, which allows you to split a long time range
+// into several requests.
+func StreamByResource(c *gophercloud.ServiceClient, resourceType, resourceID, metricName string, opts StreamOpts) *MeasureIterator {
+	return stream(c, listByResourceURL(c, resourceType, resourceID, metricName), opts)
+}
+
+// stream prepares a MeasureIterator that lazily requests every chunk of measures.
+func stream(c *gophercloud.ServiceClient, url string, opts StreamOpts) *MeasureIterator {
+	chunks, err := opts.chunks()
+	if err != nil {
+		return &MeasureIterator{err: err}
+	}
+
+	return &MeasureIterator{
+		open: func() (io.ReadCloser, error) {
+			if len(chunks) == 0 {
+				return nil, io.EOF
+			}
+			query, err := chunks[0].ToMeasureListQuery()
+			if err != nil {
+				return nil, err
+			}
+			chunks = chunks[1:]
+
+			resp, err := c.Get(url+query, nil, &gophercloud.RequestOpts{
+				OkCodes: []int{200},
+			})
+			if err != nil {
+				return nil, err
+			}
+			return resp.Body, nil
+		},
+	}
+}
+
 // CreateOptsBuilder is needed to add measures to the Create request.
 type CreateOptsBuilder interface {
 	ToMeasureCreateMap() (map[string]interface{}, error)
@@ -136,6 +318,23 @@ func Create(client *gophercloud.ServiceClient, metricID string, opts CreateOptsB
 	return
 }
 
+// CreateByResource requests the creation of a new measures in the single Gnocchi metric
+// that is referenced by its resource and name.
+func CreateByResource(client *gophercloud.ServiceClient, resourceType, resourceID, metricName string, opts CreateOptsBuilder) (r CreateResult) {
+	b, err := opts.ToMeasureCreateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Post(createByResourceURL(client, resourceType, resourceID, metricName), b["measures"], &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{202},
+		MoreHeaders: map[string]string{
+			"Accept": "application/json, */*",
+		},
+	})
+	return
+}
+
 // BatchCreateMetricsOptsBuilder is needed to add measures to the BatchCreateMetrics request.
 type BatchCreateMetricsOptsBuilder interface {
 	ToMeasuresBatchCreateMetricsMap() (map[string]interface{}, error)
@@ -158,11 +357,11 @@ func (opts MetricOpts) ToMap() (map[string]interface{}, error) {
 	// Check provided MetricOpts fields.
 	if opts.ID == "" {
 		errMsg := "missing input for the MetricOpts 'ID' argument"
-		return nil, fmt.Errorf(errMsg)
+		return nil, fmt.Errorf("%s", errMsg)
 	}
 	if opts.Measures == nil {
 		errMsg := "missing input for the MetricOpts 'Measures' argument"
-		return nil, fmt.Errorf(errMsg)
+		return nil, fmt.Errorf("%s", errMsg)
 	}
 
 	// measures is a slice of measures maps.
@@ -250,11 +449,11 @@ func (opts BatchResourcesMetricsOpts) ToMap() (map[string]interface{}, error) {
 	// Check provided BatchResourcesMetricsOpts fields.
 	if opts.ResourceID == "" {
 		errMsg := "missing input for the BatchResourcesMetricsOpts 'ResourceID' argument"
-		return nil, fmt.Errorf(errMsg)
+		return nil, fmt.Errorf("%s", errMsg)
 	}
 	if opts.ResourcesMetrics == nil {
 		errMsg := "missing input for the BatchResourcesMetricsOpts 'ResourcesMetrics' argument"
-		return nil, fmt.Errorf(errMsg)
+		return nil, fmt.Errorf("%s", errMsg)
 	}
 
 	// batchResourcesMetricsOpts is an internal map representation of the BatchResourcesMetricsOpts struct.
@@ -309,11 +508,11 @@ func (opts ResourcesMetricsOpts) ToMap() (map[string]interface{}, error) {
 	// Check provided ResourcesMetricsOpts fields.
 	if opts.MetricName == "" {
 		errMsg := "missing input for the ResourcesMetricsOpts 'MetricName' argument"
-		return nil, fmt.Errorf(errMsg)
+		return nil, fmt.Errorf("%s", errMsg)
 	}
 	if opts.Measures == nil {
 		errMsg := "missing input for the ResourcesMetricsOpts 'Measures' argument"
-		return nil, fmt.Errorf(errMsg)
+		return nil, fmt.Errorf("%s", errMsg)
 	}
 
 	// measures is a slice of measures maps.
@@ -3,6 +3,8 @@ package measures
 import (
 	"encoding/json"
 	"fmt"
+	"io"
+	"math"
 	"time"
 
 	"github.com/gophercloud/utils/gnocchi"
@@ -38,6 +40,7 @@ type Measure struct {
 	Granularity float64 `json:"-"`
 
 	// Value represents a value of data that was pushed into the Gnocchi.
+	// It's NaN for missing points that were filled with the "null" value.
 	Value float64 `json:"-"`
 }
 
@@ -71,7 +74,7 @@ func (r *Measure) UnmarshalJSON(b []byte) error {
 	// We need to check that a measure contains all needed data.
 	if len(measuresSlice) != 3 {
 		errMsg := fmt.Sprintf("got an invalid measure: %v", measuresSlice)
-		return fmt.Errorf(errMsg)
+		return fmt.Errorf("%s", errMsg)
 	}
 
 	type tmp Measure
@@ -85,7 +88,7 @@ func (r *Measure) UnmarshalJSON(b []byte) error {
 	var ok bool
 	if timeStamp, ok = measuresSlice[0].(string); !ok {
 		errMsg := fmt.Sprintf("got an invalid timestamp of a measure %v: %v", measuresSlice, measuresSlice[0])
-		return fmt.Errorf(errMsg)
+		return fmt.Errorf("%s", errMsg)
 	}
 	r.Timestamp, err = time.Parse(gnocchi.RFC3339NanoTimezone, timeStamp)
 	if err != nil {
@@ -95,13 +98,18 @@ func (r *Measure) UnmarshalJSON(b []byte) error {
 	// Populate a measure's granularity.
 	if r.Granularity, ok = measuresSlice[1].(float64); !ok {
 		errMsg := fmt.Sprintf("got an invalid granularity of a measure %v: %v", measuresSlice, measuresSlice[1])
-		return fmt.Errorf(errMsg)
+		return fmt.Errorf("%s", errMsg)
 	}
 
-	// Populate a measure's value.
-	if r.Value = measuresSlice[2].(float64); !ok {
+	// Populate a measure's value. Gnocchi returns null values for missing
+	// points that were filled with the "null" fill option.
+	if measuresSlice[2] == nil {
+		r.Value = math.NaN()
+		return nil
+	}
+	if r.Value, ok = measuresSlice[2].(float64); !ok {
 		errMsg := fmt.Sprintf("got an invalid value of a measure %v: %v", measuresSlice, measuresSlice[2])
-		return fmt.Errorf(errMsg)
+		return fmt.Errorf("%s", errMsg)
 	}
 
 	return nil
@@ -131,3 +139,182 @@ func ExtractMeasures(r pagination.Page) ([]Measure, error) {
 
 	return s, err
 }
+
+// MeasureIterator decodes Gnocchi measures one at a time from one or several
+// response bodies. Call its Next method to advance to the next measure and
+// its Measure method to retrieve it:
+//
+//	for iter.Next() {
+//		measure := iter.Measure()
+//	}
+//	if err := iter.Err(); err != nil {
+//		panic(err)
+//	}
+type MeasureIterator struct {
+	// open returns the next body to decode or io.EOF if there are no bodies left.
+	open func() (io.ReadCloser, error)
+
+	body    io.ReadCloser
+	decoder *json.Decoder
+	current Measure
+	err     error
+
+	// latest contains a timestamp of the latest measure for every granularity.
+	// It's used to skip measures that are repeated in consecutive chunks.
+	latest map[float64]time.Time
+}
+
+// NewMeasureIterator returns a MeasureIterator that decodes measures from the
+// provided body, for example from a body of the raw Gnocchi measures List response.
+// The body is closed when all measures are read or the iterator is closed.
+func NewMeasureIterator(body io.ReadCloser) *MeasureIterator {
+	opened := false
+	return &MeasureIterator{
+		open: func() (io.ReadCloser, error) {
+			if opened {
+				return nil, io.EOF
+			}
+			opened = true
+			return body, nil
+		},
+	}
+}
+
+// Next advances the iterator to the next measure. It returns false when there
+// are no measures left or an error occurred.
+func (iter *MeasureIterator) Next() bool {
+	if iter.err != nil {
+		return false
+	}
+
+	for {
+		if iter.decoder == nil {
+			if !iter.openBody() {
+				return false
+			}
+		}
+
+		if !iter.decoder.More() {
+			// Consume the closing bracket of the measures list.
+			if _, err := iter.decoder.Token(); err != nil {
+				iter.fail(err)
+				return false
+			}
+			if err := iter.closeBody(); err != nil {
+				iter.fail(err)
+				return false
+			}
+			continue
+		}
+
+		var measure Measure
+		if err := iter.decoder.Decode(&measure); err != nil {
+			iter.fail(err)
+			return false
+		}
+
+		// Measures with the coarse granularities can be repeated in
+		// consecutive chunks since Gnocchi rounds the start of a time range.
+		if latest, ok := iter.latest[measure.Granularity]; ok && !measure.Timestamp.After(latest) {
+			continue
+		}
+		if iter.latest == nil {
+			iter.latest = make(map[float64]time.Time)
+		}
+		iter.latest[measure.Granularity] = measure.Timestamp
+		iter.current = measure
+
+		return true
+	}
+}
+
+// Measure returns the current measure of the iterator.
+func (iter *MeasureIterator) Measure() Measure {
+	return iter.current
+}
+
+// Err returns the first error that was encountered by the iterator.
+func (iter *MeasureIterator) Err() error {
+	return iter.err
+}
+
+// Close releases the response body that is currently read by the iterator.
+// It's only needed if the iteration was stopped before all measures were read.
+func (iter *MeasureIterator) Close() error {
+	iter.open = nil
+	if iter.body == nil {
+		return nil
+	}
+	err := iter.body.Close()
+	iter.body = nil
+	iter.decoder = nil
+	return err
+}
+
+// EachMeasure calls the provided handler for every measure of the iterator.
+// Return "false" from the handler to prematurely stop iterating.
+func (iter *MeasureIterator) EachMeasure(handler func(Measure) (bool, error)) error {
+	defer iter.Close()
+
+	for iter.Next() {
+		ok, err := handler(iter.Measure())
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+	}
+
+	return iter.Err()
+}
+
+// openBody opens the next body and consumes the opening bracket of its
+// measures list. It returns false if there are no bodies left.
+func (iter *MeasureIterator) openBody() bool {
+	if iter.open == nil {
+		return false
+	}
+
+	body, err := iter.open()
+	if err == io.EOF {
+		iter.open = nil
+		return false
+	}
+	if err != nil {
+		iter.fail(err)
+		return false
+	}
+	iter.body = body
+	iter.decoder = json.NewDecoder(body)
+
+	token, err := iter.decoder.Token()
+	if err != nil {
+		iter.fail(err)
+		return false
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		iter.fail(fmt.Errorf("got an invalid measures list: %v", token))
+		return false
+	}
+
+	return true
+}
+
+// closeBody closes the body that was fully read.
+func (iter *MeasureIterator) closeBody() error {
+	err := iter.body.Close()
+	iter.body = nil
+	iter.decoder = nil
+	return err
+}
+
+// fail stops the iteration with the provided error.
+func (iter *MeasureIterator) fail(err error) {
+	iter.err = err
+	if iter.body != nil {
+		iter.body.Close()
+		iter.body = nil
+		iter.decoder = nil
+	}
+}
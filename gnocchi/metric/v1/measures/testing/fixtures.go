@@ -137,3 +137,135 @@ const MeasuresBatchCreateResourcesMetricsRequest = `
     }
 }
 `
+
+// MeasuresStreamFirstChunkResult represents a raw server response to the
+// first chunk of a Stream request.
+const MeasuresStreamFirstChunkResult = `
+[
+    [
+        "2018-01-10T00:00:00+00:00",
+        86400.0,
+        12.5
+    ],
+    [
+        "2018-01-10T12:00:00+00:00",
+        3600.0,
+        15.0
+    ],
+    [
+        "2018-01-10T13:00:00+00:00",
+        3600.0,
+        10.0
+    ]
+]
+`
+
+// MeasuresStreamSecondChunkResult represents a raw server response to the
+// second chunk of a Stream request. It repeats measures that were rounded
+// to the start of their granularity.
+const MeasuresStreamSecondChunkResult = `
+[
+    [
+        "2018-01-10T00:00:00+00:00",
+        86400.0,
+        12.5
+    ],
+    [
+        "2018-01-10T13:00:00+00:00",
+        3600.0,
+        10.0
+    ],
+    [
+        "2018-01-10T14:00:00+00:00",
+        3600.0,
+        20.0
+    ]
+]
+`
+
+// StreamMeasuresChunksExpected represents an expected result of a chunked Stream request.
+var StreamMeasuresChunksExpected = []measures.Measure{
+	{
+		Timestamp:   time.Date(2018, 1, 10, 0, 0, 0, 0, time.UTC),
+		Granularity: 86400.0,
+		Value:       12.5,
+	},
+	{
+		Timestamp:   time.Date(2018, 1, 10, 12, 0, 0, 0, time.UTC),
+		Granularity: 3600.0,
+		Value:       15.0,
+	},
+	{
+		Timestamp:   time.Date(2018, 1, 10, 13, 0, 0, 0, time.UTC),
+		Granularity: 3600.0,
+		Value:       10.0,
+	},
+	{
+		Timestamp:   time.Date(2018, 1, 10, 14, 0, 0, 0, time.UTC),
+		Granularity: 3600.0,
+		Value:       20.0,
+	},
+}
+
+// MeasuresWriterBatchCreateMetricsRequest represents a request that is sent
+// by the Writer to create measures for different metrics.
+const MeasuresWriterBatchCreateMetricsRequest = `
+{
+    "777a01d6-4694-49cb-b86a-5ba9fd4e609e": [
+        {
+            "timestamp": "2018-01-18T12:31:00",
+            "value": 200
+        },
+        {
+            "timestamp": "2018-01-18T14:32:00",
+            "value": 300
+        }
+    ],
+    "6dbc97c5-bfdf-47a2-b184-02e7fa348d21": [
+        {
+            "timestamp": "2018-01-18T12:31:00",
+            "value": 111
+        }
+    ]
+}
+`
+
+// MeasuresWriterBatchCreateResourcesMetricsRequest represents a request that
+// is sent by the Writer to create measures via resource IDs and metric names.
+const MeasuresWriterBatchCreateResourcesMetricsRequest = `
+{
+    "75274f99-faf6-4112-a6d5-2794cb07c789": {
+        "network.incoming.bytes.rate": {
+            "archive_policy_name": "high",
+            "unit": "B/s",
+            "measures": [
+                {
+                    "timestamp": "2018-01-20T12:30:00",
+                    "value": 1562.82
+                },
+                {
+                    "timestamp": "2018-01-20T13:30:00",
+                    "value": 768.1
+                }
+            ]
+        }
+    }
+}
+`
+
+// MeasuresListFilledResult represents a raw server response from a server to a
+// List call with the "null" fill option.
+const MeasuresListFilledResult = `
+[
+    [
+        "2018-01-10T12:00:00+00:00",
+        3600.0,
+        0.5
+    ],
+    [
+        "2018-01-10T13:00:00+00:00",
+        3600.0,
+        null
+    ]
+]
+`
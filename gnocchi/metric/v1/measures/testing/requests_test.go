@@ -2,12 +2,17 @@ package testing
 
 import (
 	"fmt"
+	"io/ioutil"
+	"math"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/gophercloud/gophercloud/pagination"
 	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/utils/gnocchi"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/archivepolicies"
 	"github.com/gophercloud/utils/gnocchi/metric/v1/measures"
 	fake "github.com/gophercloud/utils/gnocchi/testhelper/client"
 )
@@ -32,7 +37,7 @@ func TestListMeasures(t *testing.T) {
 	opts := measures.ListOpts{
 		Start:       &startTime,
 		Stop:        &stopTime,
-		Granularity: "1h",
+		Granularity: gnocchi.Duration(time.Hour),
 	}
 	expected := ListMeasuresExpected
 	pages := 0
@@ -53,6 +58,204 @@ func TestListMeasures(t *testing.T) {
 	th.CheckEquals(t, 1, pages)
 }
 
+func TestListMeasuresByResource(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/resource/compute_instance/1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc/metric/cpu_util/measures", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{
+			"granularity": "3600",
+		})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprintf(w, MeasuresListResult)
+	})
+
+	opts := measures.ListOpts{
+		Granularity: gnocchi.Duration(time.Hour),
+	}
+	allPages, err := measures.ListByResource(fake.ServiceClient(), "compute_instance", "1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc", "cpu_util", opts).AllPages()
+	th.AssertNoErr(t, err)
+
+	actual, err := measures.ExtractMeasures(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, ListMeasuresExpected, actual)
+}
+
+func TestListMeasuresWithTransforms(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/metric/9e5a6441-1044-4181-b66e-34e180753040/measures", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{
+			"aggregation": "rate:mean",
+			"fill":        "null",
+			"limit":       "2",
+			"transform":   "absolute:resample(mean,3600)",
+		})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprintf(w, MeasuresListFilledResult)
+	})
+
+	opts := measures.ListOpts{
+		Aggregation: "rate:mean",
+		Fill:        "null",
+		Limit:       2,
+		Transform: []measures.Transform{
+			measures.TransformAbsolute,
+			measures.TransformResample("mean", gnocchi.Duration(time.Hour)),
+		},
+	}
+	allPages, err := measures.List(fake.ServiceClient(), "9e5a6441-1044-4181-b66e-34e180753040", opts).AllPages()
+	th.AssertNoErr(t, err)
+
+	actual, err := measures.ExtractMeasures(allPages)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2, len(actual))
+	th.AssertEquals(t, 0.5, actual[0].Value)
+	th.AssertEquals(t, time.Date(2018, 1, 10, 13, 0, 0, 0, time.UTC), actual[1].Timestamp)
+	if !math.IsNaN(actual[1].Value) {
+		t.Fatalf("Expected NaN value for a filled measure, got %v", actual[1].Value)
+	}
+}
+
+func TestListMeasuresUnknownAggregation(t *testing.T) {
+	opts := measures.ListOpts{
+		Aggregation: "rate:average",
+	}
+	_, err := opts.ToMeasureListQuery()
+	if err == nil {
+		t.Fatal("Expected an error for an unknown aggregation method")
+	}
+}
+
+func TestValidateAggregation(t *testing.T) {
+	archivePolicy := archivepolicies.ArchivePolicy{
+		Name:               "high",
+		AggregationMethods: []string{"mean", "95pct", "rate:mean"},
+	}
+
+	th.AssertNoErr(t, measures.ValidateAggregation("mean", archivePolicy))
+	th.AssertNoErr(t, measures.ValidateAggregation("95pct", archivePolicy))
+	th.AssertNoErr(t, measures.ValidateAggregation("rate:mean", archivePolicy))
+
+	for _, aggregation := range []string{"max", "rate:max", "rate:unknown"} {
+		if err := measures.ValidateAggregation(aggregation, archivePolicy); err == nil {
+			t.Fatalf("Expected an error for the %q aggregation method", aggregation)
+		}
+	}
+}
+
+func TestStreamMeasures(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/metric/9e5a6441-1044-4181-b66e-34e180753040/measures", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{
+			"granularity": "3600",
+		})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprintf(w, MeasuresListResult)
+	})
+
+	opts := measures.StreamOpts{
+		ListOpts: measures.ListOpts{
+			Granularity: gnocchi.Duration(time.Hour),
+		},
+	}
+	var actual []measures.Measure
+	iter := measures.Stream(fake.ServiceClient(), "9e5a6441-1044-4181-b66e-34e180753040", opts)
+	for iter.Next() {
+		actual = append(actual, iter.Measure())
+	}
+	th.AssertNoErr(t, iter.Err())
+	th.CheckDeepEquals(t, ListMeasuresExpected, actual)
+}
+
+func TestStreamMeasuresChunks(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	requests := 0
+	th.Mux.HandleFunc("/v1/metric/9e5a6441-1044-4181-b66e-34e180753040/measures", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		requests++
+
+		w.Header().Add("Content-Type", "application/json")
+		switch requests {
+		case 1:
+			th.TestFormValues(t, r, map[string]string{
+				"start": "2018-01-10T12:00:00",
+				"stop":  "2018-01-10T13:30:00",
+			})
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, MeasuresStreamFirstChunkResult)
+		case 2:
+			th.TestFormValues(t, r, map[string]string{
+				"start": "2018-01-10T13:30:00",
+				"stop":  "2018-01-10T14:05:00",
+			})
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, MeasuresStreamSecondChunkResult)
+		default:
+			t.Fatalf("Unexpected request number %d", requests)
+		}
+	})
+
+	startTime := time.Date(2018, 1, 10, 12, 0, 0, 0, time.UTC)
+	stopTime := time.Date(2018, 1, 10, 14, 5, 0, 0, time.UTC)
+	opts := measures.StreamOpts{
+		ListOpts: measures.ListOpts{
+			Start: &startTime,
+			Stop:  &stopTime,
+		},
+		ChunkSize: 90 * time.Minute,
+	}
+	var actual []measures.Measure
+	err := measures.Stream(fake.ServiceClient(), "9e5a6441-1044-4181-b66e-34e180753040", opts).EachMeasure(func(m measures.Measure) (bool, error) {
+		actual = append(actual, m)
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, 2, requests)
+	th.CheckDeepEquals(t, StreamMeasuresChunksExpected, actual)
+}
+
+func TestStreamMeasuresChunksWithoutRange(t *testing.T) {
+	opts := measures.StreamOpts{
+		ChunkSize: time.Hour,
+	}
+	iter := measures.Stream(fake.ServiceClient(), "9e5a6441-1044-4181-b66e-34e180753040", opts)
+	th.CheckEquals(t, false, iter.Next())
+	if iter.Err() == nil {
+		t.Fatal("Expected an error for chunks without a time range")
+	}
+}
+
+func TestMeasureIteratorInvalidBody(t *testing.T) {
+	body := ioutil.NopCloser(strings.NewReader(`[["2018-01-10T12:00:00+00:00", 3600.0, "invalid"]]`))
+	iter := measures.NewMeasureIterator(body)
+	th.CheckEquals(t, false, iter.Next())
+	if iter.Err() == nil {
+		t.Fatal("Expected an error for an invalid measure value")
+	}
+}
+
 func TestCreateMeasures(t *testing.T) {
 	th.SetupHTTP()
 	defer th.TeardownHTTP()
@@ -85,6 +288,38 @@ func TestCreateMeasures(t *testing.T) {
 	th.AssertNoErr(t, res.Err)
 }
 
+func TestCreateMeasuresByResource(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/resource/compute_instance/1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc/metric/cpu_util/measures", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json, */*")
+		th.TestJSONRequest(t, r, MeasuresCreateRequest)
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, `{}`)
+	})
+
+	firstMeasureTimestamp := time.Date(2018, 1, 18, 12, 31, 0, 0, time.UTC)
+	secondMeasureTimestamp := time.Date(2018, 1, 18, 14, 32, 0, 0, time.UTC)
+	createOpts := measures.CreateOpts{
+		Measures: []measures.MeasureOpts{
+			{
+				Timestamp: &firstMeasureTimestamp,
+				Value:     101.2,
+			},
+			{
+				Timestamp: &secondMeasureTimestamp,
+				Value:     102,
+			},
+		},
+	}
+	res := measures.CreateByResource(fake.ServiceClient(), "compute_instance", "1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc", "cpu_util", createOpts)
+	th.AssertNoErr(t, res.Err)
+}
+
 func TestBatchCreateMetrics(t *testing.T) {
 	th.SetupHTTP()
 	defer th.TeardownHTTP()
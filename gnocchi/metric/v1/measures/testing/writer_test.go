@@ -0,0 +1,246 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/measures"
+	fake "github.com/gophercloud/utils/gnocchi/testhelper/client"
+)
+
+func TestWriterFlushBySize(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	requests := make(chan struct{}, 1)
+	th.Mux.HandleFunc("/v1/batch/metrics/measures", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestJSONRequest(t, r, MeasuresWriterBatchCreateMetricsRequest)
+
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, `{}`)
+		requests <- struct{}{}
+	})
+
+	writer, err := measures.NewWriter(fake.ServiceClient(), measures.WriterOpts{
+		BatchSize:     3,
+		FlushInterval: time.Hour,
+	})
+	th.AssertNoErr(t, err)
+
+	firstTimestamp := time.Date(2018, 1, 18, 12, 31, 0, 0, time.UTC)
+	secondTimestamp := time.Date(2018, 1, 18, 14, 32, 0, 0, time.UTC)
+	th.AssertNoErr(t, writer.WriteMetric("777a01d6-4694-49cb-b86a-5ba9fd4e609e", measures.MeasureOpts{
+		Timestamp: &firstTimestamp,
+		Value:     200,
+	}))
+	th.AssertNoErr(t, writer.WriteMetric("6dbc97c5-bfdf-47a2-b184-02e7fa348d21", measures.MeasureOpts{
+		Timestamp: &firstTimestamp,
+		Value:     111,
+	}))
+	th.CheckEquals(t, 2, writer.Stats().Buffered)
+
+	th.AssertNoErr(t, writer.WriteMetric("777a01d6-4694-49cb-b86a-5ba9fd4e609e", measures.MeasureOpts{
+		Timestamp: &secondTimestamp,
+		Value:     300,
+	}))
+
+	select {
+	case <-requests:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the Writer to flush a full buffer")
+	}
+
+	th.AssertNoErr(t, writer.Close())
+	th.CheckDeepEquals(t, measures.WriterStats{Written: 3, Batches: 1}, writer.Stats())
+}
+
+func TestWriterCloseDrainsBuffer(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	requests := 0
+	th.Mux.HandleFunc("/v1/batch/resources/metrics/measures", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{
+			"create_metrics": "true",
+		})
+		th.TestJSONRequest(t, r, MeasuresWriterBatchCreateResourcesMetricsRequest)
+		requests++
+
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, `{}`)
+	})
+
+	writer, err := measures.NewWriter(fake.ServiceClient(), measures.WriterOpts{
+		FlushInterval: time.Hour,
+		CreateMetrics: true,
+	})
+	th.AssertNoErr(t, err)
+
+	timestamp := time.Date(2018, 1, 20, 12, 30, 0, 0, time.UTC)
+	for _, value := range []float64{1562.82, 768.1} {
+		th.AssertNoErr(t, writer.WriteResourceMetric("75274f99-faf6-4112-a6d5-2794cb07c789", measures.ResourcesMetricsOpts{
+			MetricName:        "network.incoming.bytes.rate",
+			ArchivePolicyName: "high",
+			Unit:              "B/s",
+			Measures: []measures.MeasureOpts{
+				{
+					Timestamp: &timestamp,
+					Value:     value,
+				},
+			},
+		}))
+		timestamp = timestamp.Add(time.Hour)
+	}
+
+	th.AssertNoErr(t, writer.Close())
+	th.CheckEquals(t, 1, requests)
+	th.CheckDeepEquals(t, measures.WriterStats{Written: 2, Batches: 1}, writer.Stats())
+
+	err = writer.WriteMetric("777a01d6-4694-49cb-b86a-5ba9fd4e609e", measures.MeasureOpts{
+		Timestamp: &timestamp,
+		Value:     1,
+	})
+	if err == nil {
+		t.Fatal("Expected an error for a write into a closed Writer")
+	}
+}
+
+func TestWriterRetries(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	requests := 0
+	th.Mux.HandleFunc("/v1/batch/metrics/measures", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		requests++
+
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		fmt.Fprintf(w, `{}`)
+	})
+
+	writer, err := measures.NewWriter(fake.ServiceClient(), measures.WriterOpts{
+		FlushInterval: time.Hour,
+		MaxRetries:    2,
+		RetryInterval: time.Millisecond,
+	})
+	th.AssertNoErr(t, err)
+
+	timestamp := time.Date(2018, 1, 18, 12, 31, 0, 0, time.UTC)
+	th.AssertNoErr(t, writer.WriteMetric("777a01d6-4694-49cb-b86a-5ba9fd4e609e", measures.MeasureOpts{
+		Timestamp: &timestamp,
+		Value:     200,
+	}))
+	th.AssertNoErr(t, writer.Flush())
+	th.AssertNoErr(t, writer.Close())
+
+	th.CheckEquals(t, 2, requests)
+	th.CheckDeepEquals(t, measures.WriterStats{Written: 1, Batches: 1, Retries: 1}, writer.Stats())
+}
+
+func TestWriterErrors(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	requests := 0
+	th.Mux.HandleFunc("/v1/batch/metrics/measures", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		requests++
+
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	var mu sync.Mutex
+	var handled []error
+	writer, err := measures.NewWriter(fake.ServiceClient(), measures.WriterOpts{
+		FlushInterval: time.Hour,
+		MaxRetries:    2,
+		RetryInterval: time.Millisecond,
+		ErrorHandler: func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			handled = append(handled, err)
+		},
+	})
+	th.AssertNoErr(t, err)
+
+	timestamp := time.Date(2018, 1, 18, 12, 31, 0, 0, time.UTC)
+	th.AssertNoErr(t, writer.WriteMetric("777a01d6-4694-49cb-b86a-5ba9fd4e609e", measures.MeasureOpts{
+		Timestamp: &timestamp,
+		Value:     200,
+	}))
+
+	err = writer.Close()
+	if _, ok := err.(gophercloud.ErrDefault400); !ok {
+		t.Fatalf("Expected the 400 error, got %v", err)
+	}
+
+	// Bad requests aren't retried.
+	th.CheckEquals(t, 1, requests)
+	th.CheckEquals(t, 1, len(handled))
+	th.CheckDeepEquals(t, measures.WriterStats{Failed: 1}, writer.Stats())
+}
+
+func TestWriterFlushWaitsForSentBatches(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	release := make(chan struct{})
+	th.Mux.HandleFunc("/v1/batch/metrics/measures", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		<-release
+
+		w.WriteHeader(http.StatusBadRequest)
+	})
+
+	writer, err := measures.NewWriter(fake.ServiceClient(), measures.WriterOpts{
+		BatchSize:     1,
+		FlushInterval: time.Hour,
+	})
+	th.AssertNoErr(t, err)
+
+	// The full buffer is passed to a worker, so Flush has nothing to send
+	// but has to wait for that batch.
+	timestamp := time.Date(2018, 1, 18, 12, 31, 0, 0, time.UTC)
+	th.AssertNoErr(t, writer.WriteMetric("777a01d6-4694-49cb-b86a-5ba9fd4e609e", measures.MeasureOpts{
+		Timestamp: &timestamp,
+		Value:     200,
+	}))
+	th.CheckEquals(t, 0, writer.Stats().Buffered)
+
+	flushed := make(chan error, 1)
+	go func() {
+		flushed <- writer.Flush()
+	}()
+
+	select {
+	case err := <-flushed:
+		t.Fatalf("Expected Flush to wait for the sent batch, got %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+
+	err = <-flushed
+	if _, ok := err.(gophercloud.ErrDefault400); !ok {
+		t.Fatalf("Expected the 400 error, got %v", err)
+	}
+
+	// Batches that finished before are not waited again.
+	th.AssertNoErr(t, writer.Flush())
+
+	if _, ok := writer.Close().(gophercloud.ErrDefault400); !ok {
+		t.Fatal("Expected Close to return the 400 error")
+	}
+}
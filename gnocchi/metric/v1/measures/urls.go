@@ -4,6 +4,7 @@ import "github.com/gophercloud/gophercloud"
 
 const (
 	resourcePath                    = "metric"
+	resourceMetricPath              = "resource"
 	batchCreateMetricsPath          = "batch/metrics"
 	batchCreateResourcesMetricsPath = "batch/resources/metrics"
 )
@@ -20,6 +21,18 @@ func createURL(c *gophercloud.ServiceClient, metricID string) string {
 	return resourceURL(c, metricID)
 }
 
+func resourceMetricURL(c *gophercloud.ServiceClient, resourceType, resourceID, metricName string) string {
+	return c.ServiceURL(resourceMetricPath, resourceType, resourceID, "metric", metricName, "measures")
+}
+
+func listByResourceURL(c *gophercloud.ServiceClient, resourceType, resourceID, metricName string) string {
+	return resourceMetricURL(c, resourceType, resourceID, metricName)
+}
+
+func createByResourceURL(c *gophercloud.ServiceClient, resourceType, resourceID, metricName string) string {
+	return resourceMetricURL(c, resourceType, resourceID, metricName)
+}
+
 func batchCreateMetricsURL(c *gophercloud.ServiceClient) string {
 	return c.ServiceURL(batchCreateMetricsPath, "measures")
 }
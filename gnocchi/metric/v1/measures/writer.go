@@ -0,0 +1,473 @@
+package measures
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+const (
+	// DefaultWriterBatchSize is a default number of buffered measures that
+	// triggers a flush of the Writer.
+	DefaultWriterBatchSize = 1000
+
+	// DefaultWriterFlushInterval is a default maximum time that measures
+	// are kept in the Writer buffer.
+	DefaultWriterFlushInterval = 10 * time.Second
+
+	// DefaultWriterWorkers is a default number of concurrent Writer flushes.
+	DefaultWriterWorkers = 4
+
+	// DefaultWriterRetryInterval is a default time between retries of a
+	// failed Writer batch.
+	DefaultWriterRetryInterval = time.Second
+)
+
+// WriterOpts specifies parameters of the Writer.
+type WriterOpts struct {
+	// BatchSize is a number of buffered measures that triggers a flush.
+	// DefaultWriterBatchSize is used if it's zero.
+	BatchSize int
+
+	// FlushInterval is a maximum time that measures are kept in the buffer.
+	// DefaultWriterFlushInterval is used if it's zero.
+	FlushInterval time.Duration
+
+	// Workers is a maximum number of batches that are sent concurrently.
+	// DefaultWriterWorkers is used if it's zero.
+	Workers int
+
+	// MaxRetries is a number of times a failed batch is sent again.
+	// Batches that were rejected with the 400 or 404 status codes aren't retried.
+	MaxRetries int
+
+	// RetryInterval is a time between retries of a failed batch.
+	// DefaultWriterRetryInterval is used if it's zero.
+	RetryInterval time.Duration
+
+	// CreateMetrics allows Gnocchi to create metrics that don't exist yet
+	// when measures are written via resource IDs and metric names.
+	CreateMetrics bool
+
+	// ErrorHandler is called for every batch that failed after all retries.
+	// It's called from the worker goroutines so it must be safe for concurrent use.
+	ErrorHandler func(err error)
+}
+
+// WriterStats contains counters of the Writer.
+type WriterStats struct {
+	// Buffered is a number of measures that are waiting for a flush.
+	Buffered int
+
+	// Written is a number of measures that were accepted by Gnocchi.
+	Written int
+
+	// Failed is a number of measures that were dropped after all retries.
+	Failed int
+
+	// Batches is a number of batch requests that were accepted by Gnocchi.
+	Batches int
+
+	// Retries is a number of batch requests that were sent again.
+	Retries int
+}
+
+// Writer accumulates measures in a buffer and sends them to Gnocchi with
+// the BatchCreateMetrics and BatchCreateResourcesMetrics requests.
+//
+// The buffer is flushed once it contains WriterOpts.BatchSize measures and
+// every WriterOpts.FlushInterval. Call the Close method to flush remaining
+// measures and stop the Writer.
+type Writer struct {
+	client *gophercloud.ServiceClient
+	opts   WriterOpts
+
+	mu        sync.Mutex
+	metrics   map[string][]MeasureOpts
+	resources map[string]map[string]ResourcesMetricsOpts
+	buffered  int
+	closed    bool
+	err       error
+	stats     WriterStats
+
+	// inflight contains batches that were taken from the buffer and
+	// aren't accepted or failed yet.
+	inflight map[*writerJob]struct{}
+
+	// submitting tracks flushes that are passing batches to the workers.
+	submitting sync.WaitGroup
+	workers    sync.WaitGroup
+	jobs       chan *writerJob
+	stop       chan struct{}
+	ticker     sync.WaitGroup
+}
+
+// writerJob is a single batch request that is sent by a worker.
+type writerJob struct {
+	measures int
+	send     func() error
+
+	// done is closed once the batch is accepted by Gnocchi or failed after
+	// all retries. err is set before done is closed.
+	done chan struct{}
+	err  error
+}
+
+// NewWriter creates a Writer and starts its workers.
+func NewWriter(client *gophercloud.ServiceClient, opts WriterOpts) (*Writer, error) {
+	if opts.BatchSize < 0 || opts.FlushInterval < 0 || opts.Workers < 0 || opts.MaxRetries < 0 || opts.RetryInterval < 0 {
+		return nil, fmt.Errorf("WriterOpts arguments can't be negative")
+	}
+	if opts.BatchSize == 0 {
+		opts.BatchSize = DefaultWriterBatchSize
+	}
+	if opts.FlushInterval == 0 {
+		opts.FlushInterval = DefaultWriterFlushInterval
+	}
+	if opts.Workers == 0 {
+		opts.Workers = DefaultWriterWorkers
+	}
+	if opts.RetryInterval == 0 {
+		opts.RetryInterval = DefaultWriterRetryInterval
+	}
+
+	w := &Writer{
+		client:    client,
+		opts:      opts,
+		metrics:   make(map[string][]MeasureOpts),
+		resources: make(map[string]map[string]ResourcesMetricsOpts),
+		inflight:  make(map[*writerJob]struct{}),
+		jobs:      make(chan *writerJob, opts.Workers),
+		stop:      make(chan struct{}),
+	}
+
+	w.workers.Add(opts.Workers)
+	for i := 0; i < opts.Workers; i++ {
+		go w.work()
+	}
+
+	w.ticker.Add(1)
+	go w.tick()
+
+	return w, nil
+}
+
+// WriteMetric adds measures of a metric that is referenced by its ID
+// to the buffer.
+func (w *Writer) WriteMetric(metricID string, measures ...MeasureOpts) error {
+	if metricID == "" {
+		return fmt.Errorf("missing input for the metricID argument")
+	}
+	if len(measures) == 0 {
+		return nil
+	}
+
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return fmt.Errorf("unable to write measures into a closed Writer")
+	}
+	w.metrics[metricID] = append(w.metrics[metricID], copyMeasures(measures)...)
+	w.buffered += len(measures)
+
+	return w.flushIfFull()
+}
+
+// WriteResourceMetric adds measures of a metric that is referenced by its
+// resource ID and name to the buffer. ArchivePolicyName and Unit of the
+// provided metric are used only if Gnocchi needs to create it.
+func (w *Writer) WriteResourceMetric(resourceID string, metric ResourcesMetricsOpts) error {
+	if resourceID == "" {
+		return fmt.Errorf("missing input for the resourceID argument")
+	}
+	if metric.MetricName == "" {
+		return fmt.Errorf("missing input for the ResourcesMetricsOpts 'MetricName' argument")
+	}
+	if len(metric.Measures) == 0 {
+		return nil
+	}
+
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return fmt.Errorf("unable to write measures into a closed Writer")
+	}
+	resourceMetrics, ok := w.resources[resourceID]
+	if !ok {
+		resourceMetrics = make(map[string]ResourcesMetricsOpts)
+		w.resources[resourceID] = resourceMetrics
+	}
+	buffered := resourceMetrics[metric.MetricName]
+	metric.Measures = append(buffered.Measures, copyMeasures(metric.Measures)...)
+	resourceMetrics[metric.MetricName] = metric
+	w.buffered += len(metric.Measures) - len(buffered.Measures)
+
+	return w.flushIfFull()
+}
+
+// flushIfFull passes buffered measures to the workers if there are enough
+// of them. It must be called with the locked mutex and unlocks it.
+func (w *Writer) flushIfFull() error {
+	if w.buffered < w.opts.BatchSize {
+		w.mu.Unlock()
+		return nil
+	}
+	jobs := w.takeJobs()
+	w.mu.Unlock()
+
+	w.submit(jobs)
+	return nil
+}
+
+// Flush sends all buffered measures and waits until they and all batches
+// that were flushed before are accepted by Gnocchi or failed after all
+// retries. It returns an error of a failed batch, if any.
+func (w *Writer) Flush() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return fmt.Errorf("unable to flush a closed Writer")
+	}
+	jobs := w.takeJobs()
+	inflight := make([]*writerJob, 0, len(w.inflight))
+	for job := range w.inflight {
+		inflight = append(inflight, job)
+	}
+	w.mu.Unlock()
+
+	w.submit(jobs)
+
+	var firstErr error
+	for _, job := range inflight {
+		<-job.done
+		if job.err != nil && firstErr == nil {
+			firstErr = job.err
+		}
+	}
+
+	return firstErr
+}
+
+// Close stops the Writer after all buffered measures are sent.
+// It returns the first error that occurred while sending measures, if any.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return fmt.Errorf("the Writer is already closed")
+	}
+	w.closed = true
+	jobs := w.takeJobs()
+	w.mu.Unlock()
+
+	close(w.stop)
+	w.ticker.Wait()
+
+	w.submit(jobs)
+
+	// Wait for concurrent flushes before workers are stopped.
+	w.submitting.Wait()
+	close(w.jobs)
+	w.workers.Wait()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+// Stats returns current counters of the Writer.
+func (w *Writer) Stats() WriterStats {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	stats := w.stats
+	stats.Buffered = w.buffered
+	return stats
+}
+
+// takeJobs empties the buffer and converts it into batch requests.
+// It must be called with the locked mutex.
+func (w *Writer) takeJobs() []*writerJob {
+	var jobs []*writerJob
+
+	if len(w.metrics) > 0 {
+		var opts BatchCreateMetricsOpts
+		measures := 0
+		metricIDs := make([]string, 0, len(w.metrics))
+		for id := range w.metrics {
+			metricIDs = append(metricIDs, id)
+		}
+		sort.Strings(metricIDs)
+		for _, id := range metricIDs {
+			opts = append(opts, MetricOpts{
+				ID:       id,
+				Measures: w.metrics[id],
+			})
+			measures += len(w.metrics[id])
+		}
+		jobs = append(jobs, &writerJob{
+			measures: measures,
+			send: func() error {
+				return BatchCreateMetrics(w.client, opts).ExtractErr()
+			},
+		})
+		w.metrics = make(map[string][]MeasureOpts)
+	}
+
+	if len(w.resources) > 0 {
+		opts := BatchCreateResourcesMetricsOpts{
+			CreateMetrics: w.opts.CreateMetrics,
+		}
+		measures := 0
+		resourceIDs := make([]string, 0, len(w.resources))
+		for id := range w.resources {
+			resourceIDs = append(resourceIDs, id)
+		}
+		sort.Strings(resourceIDs)
+		for _, id := range resourceIDs {
+			resourceOpts := BatchResourcesMetricsOpts{
+				ResourceID: id,
+			}
+			metricNames := make([]string, 0, len(w.resources[id]))
+			for name := range w.resources[id] {
+				metricNames = append(metricNames, name)
+			}
+			sort.Strings(metricNames)
+			for _, name := range metricNames {
+				metric := w.resources[id][name]
+				resourceOpts.ResourcesMetrics = append(resourceOpts.ResourcesMetrics, metric)
+				measures += len(metric.Measures)
+			}
+			opts.BatchResourcesMetrics = append(opts.BatchResourcesMetrics, resourceOpts)
+		}
+		jobs = append(jobs, &writerJob{
+			measures: measures,
+			send: func() error {
+				return BatchCreateResourcesMetrics(w.client, opts).ExtractErr()
+			},
+		})
+		w.resources = make(map[string]map[string]ResourcesMetricsOpts)
+	}
+
+	w.buffered = 0
+	for _, job := range jobs {
+		job.done = make(chan struct{})
+		w.inflight[job] = struct{}{}
+	}
+	if len(jobs) > 0 {
+		w.submitting.Add(1)
+	}
+
+	return jobs
+}
+
+// submit passes batch requests to the workers. It blocks while all workers
+// are busy.
+func (w *Writer) submit(jobs []*writerJob) {
+	if len(jobs) == 0 {
+		return
+	}
+	defer w.submitting.Done()
+
+	for _, job := range jobs {
+		w.jobs <- job
+	}
+}
+
+// work sends batch requests until the Writer is closed.
+func (w *Writer) work() {
+	defer w.workers.Done()
+
+	for job := range w.jobs {
+		job.err = w.send(job)
+
+		w.mu.Lock()
+		delete(w.inflight, job)
+		w.mu.Unlock()
+
+		close(job.done)
+	}
+}
+
+// send sends a single batch request and retries it if needed.
+func (w *Writer) send(job *writerJob) error {
+	var err error
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			time.Sleep(w.opts.RetryInterval)
+			w.mu.Lock()
+			w.stats.Retries++
+			w.mu.Unlock()
+		}
+
+		err = job.send()
+		if err == nil || attempt >= w.opts.MaxRetries || !isRetryable(err) {
+			break
+		}
+	}
+
+	w.mu.Lock()
+	if err == nil {
+		w.stats.Written += job.measures
+		w.stats.Batches++
+	} else {
+		w.stats.Failed += job.measures
+		if w.err == nil {
+			w.err = err
+		}
+	}
+	w.mu.Unlock()
+
+	if err != nil && w.opts.ErrorHandler != nil {
+		w.opts.ErrorHandler(err)
+	}
+
+	return err
+}
+
+// tick periodically flushes the buffer until the Writer is closed.
+func (w *Writer) tick() {
+	defer w.ticker.Done()
+
+	ticker := time.NewTicker(w.opts.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+			w.mu.Lock()
+			jobs := w.takeJobs()
+			w.mu.Unlock()
+
+			w.submit(jobs)
+		}
+	}
+}
+
+// copyMeasures copies measures with their timestamps so callers can reuse
+// them while measures are kept in the buffer.
+func copyMeasures(measures []MeasureOpts) []MeasureOpts {
+	copied := make([]MeasureOpts, len(measures))
+	for i, measure := range measures {
+		copied[i] = measure
+		if measure.Timestamp != nil {
+			timestamp := *measure.Timestamp
+			copied[i].Timestamp = &timestamp
+		}
+	}
+	return copied
+}
+
+// isRetryable checks if a failed batch request can succeed if it's sent again.
+func isRetryable(err error) bool {
+	switch err.(type) {
+	case gophercloud.ErrDefault400, gophercloud.ErrDefault404:
+		return false
+	}
+	return true
+}
@@ -54,6 +54,93 @@ archive policy rule and can assign the policy automatically
 		panic(err)
 	}
 
+Example of Searching measures of metrics by their values
+
+	startTime := time.Date(2018, 1, 4, 10, 0, 0, 0, time.UTC)
+	searchOpts := metrics.SearchOpts{
+		MetricIDs: []string{
+			"9e5a6441-1044-4181-b66e-34e180753040",
+			"01b2953e-de74-448a-a305-c84440697933",
+		},
+		Query:       metrics.Or(metrics.Gt(80), metrics.Lt(5)),
+		Start:       &startTime,
+		Granularity: gnocchi.Duration(time.Hour),
+	}
+	metricsMeasures, err := metrics.Search(gnocchiClient, searchOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	for metricID, measures := range metricsMeasures {
+		fmt.Printf("%s: %+v\n", metricID, measures)
+	}
+
+Example of Updating a metric
+
+	archivePolicyName := "low"
+	updateOpts := metrics.UpdateOpts{
+		ArchivePolicyName: &archivePolicyName,
+	}
+	metricID := "01b2953e-de74-448a-a305-c84440697933"
+	metric, err := metrics.Update(gnocchiClient, metricID, updateOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example of Migrating metrics to another archive policy
+
+	from, err := archivepolicies.Get(gnocchiClient, "high").Extract()
+	if err != nil {
+		panic(err)
+	}
+	to, err := archivepolicies.Get(gnocchiClient, "low").Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	metricIDs := []string{
+		"01b2953e-de74-448a-a305-c84440697933",
+		"777a01d6-4694-49cb-b86a-5ba9fd4e609e",
+	}
+	results := metrics.MigrateArchivePolicy(gnocchiClient, metricIDs, *from, *to)
+	for _, result := range results {
+		if result.Err != nil {
+			fmt.Printf("failed to migrate metric %s: %s\n", result.MetricID, result.Err)
+		}
+	}
+
+Example of Creating several metrics concurrently
+
+	createOpts := []metrics.CreateOptsBuilder{
+		metrics.CreateOpts{
+			ArchivePolicyName: "high",
+			Name:              "cpu.util",
+			ResourceID:        "1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc",
+		},
+		metrics.CreateOpts{
+			ArchivePolicyName: "high",
+			Name:              "memory.usage",
+			ResourceID:        "1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc",
+		},
+	}
+	createdMetrics, err := metrics.BatchCreate(gnocchiClient, createOpts, 5)
+	if batchErr, ok := err.(*metrics.BatchError); ok {
+		for _, failure := range batchErr.Failures {
+			fmt.Printf("failed to create metric #%d: %s\n", failure.Index, failure.Err)
+		}
+	}
+
+Example of Deleting several metrics concurrently
+
+	metricIDs := []string{
+		"01b2953e-de74-448a-a305-c84440697933",
+		"777a01d6-4694-49cb-b86a-5ba9fd4e609e",
+	}
+	err := metrics.BatchDelete(gnocchiClient, metricIDs, 5)
+	if batchErr, ok := err.(*metrics.BatchError); ok {
+		fmt.Printf("failed to delete metrics: %v\n", batchErr.FailedIDs())
+	}
+
 Example of Deleting a Gnocchi metric
 
 	metricID := "01b2953e-de74-448a-a305-c84440697933"
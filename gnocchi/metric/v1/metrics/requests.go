@@ -1,8 +1,16 @@
 package metrics
 
 import (
+	"fmt"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/pagination"
+	"github.com/gophercloud/utils/gnocchi"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/archivepolicies"
 )
 
 // ListOptsBuilder allows extensions to add additional parameters to the
@@ -57,9 +65,7 @@ func List(c *gophercloud.ServiceClient, opts ListOptsBuilder) pagination.Pager {
 		}
 		url += query
 	}
-	return pagination.NewPager(c, url, func(r pagination.PageResult) pagination.Page {
-		return MetricPage{pagination.SinglePageBase(r)}
-	})
+	return pagination.NewPager(c, url, newMetricPage)
 }
 
 // Get retrieves a specific Gnocchi metric based on its id.
@@ -116,6 +122,93 @@ func Create(client *gophercloud.ServiceClient, opts CreateOptsBuilder) (r Create
 	return
 }
 
+// UpdateOptsBuilder allows extensions to add additional parameters to the
+// Update request.
+type UpdateOptsBuilder interface {
+	ToMetricUpdateMap() (map[string]interface{}, error)
+}
+
+// UpdateOpts represents options used to update a Gnocchi metric.
+type UpdateOpts struct {
+	// ArchivePolicyName is a name of the Gnocchi archive policy that describes
+	// the aggregate storage policy of a metric.
+	ArchivePolicyName *string `json:"archive_policy_name,omitempty"`
+
+	// Unit is a unit of measurement for measures of that Gnocchi metric.
+	Unit *string `json:"unit,omitempty"`
+}
+
+// ToMetricUpdateMap constructs a request body from UpdateOpts.
+func (opts UpdateOpts) ToMetricUpdateMap() (map[string]interface{}, error) {
+	b, err := gophercloud.BuildRequestBody(opts, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return nil, fmt.Errorf("provided Gnocchi metric UpdateOpts don't contain any changes")
+	}
+
+	return b, nil
+}
+
+// Update accepts a UpdateOpts struct and updates an existing Gnocchi metric
+// using the values provided.
+// Gnocchi installations that don't allow metrics to be changed respond with
+// the 405 status code.
+func Update(client *gophercloud.ServiceClient, metricID string, opts UpdateOptsBuilder) (r UpdateResult) {
+	b, err := opts.ToMetricUpdateMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = client.Patch(updateURL(client, metricID), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	return
+}
+
+// MigrateArchivePolicy moves every provided metric from the "from" archive
+// policy to the "to" archive policy. Metrics are processed one by one and the
+// outcome of every metric is reported in the returned slice in the same order.
+// Metrics that already use the "to" archive policy are reported as migrated
+// without an update request. Metrics that use any other archive policy are
+// reported with an error and left untouched.
+func MigrateArchivePolicy(client *gophercloud.ServiceClient, metricIDs []string, from, to archivepolicies.ArchivePolicy) []MigrateArchivePolicyResult {
+	results := make([]MigrateArchivePolicyResult, len(metricIDs))
+	for i, metricID := range metricIDs {
+		results[i] = migrateArchivePolicy(client, metricID, from.Name, to.Name)
+	}
+	return results
+}
+
+// migrateArchivePolicy moves a single metric between archive policies.
+func migrateArchivePolicy(client *gophercloud.ServiceClient, metricID, from, to string) MigrateArchivePolicyResult {
+	result := MigrateArchivePolicyResult{
+		MetricID: metricID,
+	}
+
+	metric, err := Get(client, metricID).Extract()
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	if metric.ArchivePolicy.Name == to {
+		result.Metric = metric
+		return result
+	}
+	if metric.ArchivePolicy.Name != from {
+		result.Err = fmt.Errorf("metric %s uses the %q archive policy instead of the %q one",
+			metricID, metric.ArchivePolicy.Name, from)
+		return result
+	}
+
+	result.Metric, result.Err = Update(client, metricID, UpdateOpts{
+		ArchivePolicyName: &to,
+	}).Extract()
+	return result
+}
+
 // Delete accepts a unique ID and deletes the Gnocchi metric associated with it.
 func Delete(c *gophercloud.ServiceClient, metricID string) (r DeleteResult) {
 	requestOpts := &gophercloud.RequestOpts{
@@ -126,3 +219,217 @@ func Delete(c *gophercloud.ServiceClient, metricID string) (r DeleteResult) {
 	_, r.Err = c.Delete(deleteURL(c, metricID), requestOpts)
 	return
 }
+
+// DefaultBatchConcurrency is a default number of concurrent requests of the
+// BatchCreate and BatchDelete operations.
+const DefaultBatchConcurrency = 10
+
+// BatchCreate creates Gnocchi metrics concurrently with no more than
+// concurrency requests at the same time. DefaultBatchConcurrency is used if
+// concurrency isn't positive.
+// Created metrics are returned in the order of the provided options with nil
+// values for metrics that failed to be created. Failures are collected into
+// a *BatchError.
+func BatchCreate(client *gophercloud.ServiceClient, opts []CreateOptsBuilder, concurrency int) ([]*Metric, error) {
+	created := make([]*Metric, len(opts))
+	err := runBatch(len(opts), concurrency, func(i int) (string, error) {
+		metric, err := Create(client, opts[i]).Extract()
+		if err != nil {
+			return "", err
+		}
+		created[i] = metric
+		return metric.ID, nil
+	})
+
+	return created, err
+}
+
+// BatchDelete deletes Gnocchi metrics concurrently with no more than
+// concurrency requests at the same time. DefaultBatchConcurrency is used if
+// concurrency isn't positive.
+// Failures are collected into a *BatchError.
+func BatchDelete(client *gophercloud.ServiceClient, metricIDs []string, concurrency int) error {
+	return runBatch(len(metricIDs), concurrency, func(i int) (string, error) {
+		return metricIDs[i], Delete(client, metricIDs[i]).ExtractErr()
+	})
+}
+
+// runBatch calls the provided operation for every item of a batch
+// concurrently and collects its failures.
+func runBatch(count, concurrency int, operation func(i int) (string, error)) error {
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		failures []BatchFailure
+	)
+	semaphore := make(chan struct{}, concurrency)
+
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			id, err := operation(i)
+			if err == nil {
+				return
+			}
+
+			mu.Lock()
+			failures = append(failures, BatchFailure{
+				Index: i,
+				ID:    id,
+				Err:   err,
+			})
+			mu.Unlock()
+		}(i)
+	}
+	wg.Wait()
+
+	if len(failures) == 0 {
+		return nil
+	}
+
+	sort.Slice(failures, func(i, j int) bool {
+		return failures[i].Index < failures[j].Index
+	})
+	return &BatchError{Failures: failures}
+}
+
+// ValueQuery represents a predicate over values of the Gnocchi measures.
+// It can be built with the Eq, Ne, Lt, Le, Gt, Ge, And and Or functions.
+type ValueQuery map[string]interface{}
+
+// Eq builds a ValueQuery that matches measures whose value equals the provided one.
+func Eq(value float64) ValueQuery {
+	return ValueQuery{"eq": value}
+}
+
+// Ne builds a ValueQuery that matches measures whose value doesn't equal the provided one.
+func Ne(value float64) ValueQuery {
+	return ValueQuery{"ne": value}
+}
+
+// Lt builds a ValueQuery that matches measures whose value is less than the provided one.
+func Lt(value float64) ValueQuery {
+	return ValueQuery{"lt": value}
+}
+
+// Le builds a ValueQuery that matches measures whose value is less than or equal to the provided one.
+func Le(value float64) ValueQuery {
+	return ValueQuery{"le": value}
+}
+
+// Gt builds a ValueQuery that matches measures whose value is greater than the provided one.
+func Gt(value float64) ValueQuery {
+	return ValueQuery{"gt": value}
+}
+
+// Ge builds a ValueQuery that matches measures whose value is greater than or equal to the provided one.
+func Ge(value float64) ValueQuery {
+	return ValueQuery{"ge": value}
+}
+
+// And builds a ValueQuery that matches measures satisfying all of the provided queries.
+func And(queries ...ValueQuery) ValueQuery {
+	return ValueQuery{"and": queries}
+}
+
+// Or builds a ValueQuery that matches measures satisfying any of the provided queries.
+func Or(queries ...ValueQuery) ValueQuery {
+	return ValueQuery{"or": queries}
+}
+
+// SearchOptsBuilder allows extensions to add additional parameters to the
+// Search request.
+type SearchOptsBuilder interface {
+	// ToMetricSearchMap builds a request body.
+	ToMetricSearchMap() (map[string]interface{}, error)
+
+	// ToMetricSearchQuery builds a query string.
+	ToMetricSearchQuery() (string, error)
+}
+
+// SearchOpts specifies parameters of the Gnocchi metrics Search request.
+type SearchOpts struct {
+	// MetricIDs is a list of Gnocchi metrics IDs whose measures will be searched.
+	MetricIDs []string `q:"metric_id" required:"true"`
+
+	// Query is a predicate that measures values must satisfy.
+	Query ValueQuery
+
+	// Start is a start of time range for the searched measures.
+	Start *time.Time
+
+	// Stop is a stop of time range for the searched measures.
+	Stop *time.Time
+
+	// Aggregation is an aggregation method of the searched measures.
+	// Gnocchi uses "mean" by default.
+	Aggregation string `q:"aggregation"`
+
+	// Granularity is a granularity of the searched measures.
+	Granularity gnocchi.Duration
+}
+
+// ToMetricSearchMap constructs a request body from SearchOpts.
+func (opts SearchOpts) ToMetricSearchMap() (map[string]interface{}, error) {
+	if opts.Query == nil {
+		return nil, fmt.Errorf("missing input for the SearchOpts 'Query' argument")
+	}
+	return opts.Query, nil
+}
+
+// ToMetricSearchQuery formats a SearchOpts into a query string.
+func (opts SearchOpts) ToMetricSearchQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	if err != nil {
+		return "", err
+	}
+	params := q.Query()
+
+	if opts.Start != nil {
+		params.Add("start", opts.Start.Format(gnocchi.RFC3339NanoNoTimezone))
+	}
+
+	if opts.Stop != nil {
+		params.Add("stop", opts.Stop.Format(gnocchi.RFC3339NanoNoTimezone))
+	}
+
+	if opts.Granularity != 0 {
+		params.Add("granularity", opts.Granularity.QueryString())
+	}
+
+	q = &url.URL{RawQuery: params.Encode()}
+	return q.String(), nil
+}
+
+// Search requests measures of the specified Gnocchi metrics whose values
+// satisfy the provided query.
+func Search(c *gophercloud.ServiceClient, opts SearchOptsBuilder) (r SearchResult) {
+	url := searchURL(c)
+	query, err := opts.ToMetricSearchQuery()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	url += query
+
+	b, err := opts.ToMetricSearchMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+
+	_, r.Err = c.Post(url, b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	return
+}
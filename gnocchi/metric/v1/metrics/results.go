@@ -1,9 +1,14 @@
 package metrics
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/pagination"
+	"github.com/gophercloud/utils/gnocchi"
 	"github.com/gophercloud/utils/gnocchi/metric/v1/archivepolicies"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/measures"
 	"github.com/gophercloud/utils/gnocchi/metric/v1/resources"
 )
 
@@ -30,12 +35,91 @@ type CreateResult struct {
 	commonResult
 }
 
+// UpdateResult represents the result of an update operation. Call its Extract
+// method to interpret it as a Gnocchi metric.
+type UpdateResult struct {
+	commonResult
+}
+
+// MigrateArchivePolicyResult represents the outcome of the archive policy
+// migration of a single Gnocchi metric.
+type MigrateArchivePolicyResult struct {
+	// MetricID identifies the migrated Gnocchi metric.
+	MetricID string
+
+	// Metric is a Gnocchi metric after the migration.
+	// It's nil if the migration failed.
+	Metric *Metric
+
+	// Err is an error that occurred during the migration of the metric.
+	Err error
+}
+
 // DeleteResult represents the result of a delete operation. Call its
 // ExtractErr method to determine if the request succeeded or failed.
 type DeleteResult struct {
 	gophercloud.ErrResult
 }
 
+// BatchFailure represents a single failed item of the BatchCreate or
+// BatchDelete operations.
+type BatchFailure struct {
+	// Index is a position of the failed item in the batch.
+	Index int
+
+	// ID identifies the Gnocchi metric of the failed item.
+	// It's empty for metrics that failed to be created.
+	ID string
+
+	// Err is an error that occurred for the item.
+	Err error
+}
+
+// BatchError represents failures of the BatchCreate or BatchDelete operations.
+type BatchError struct {
+	// Failures contains failed items ordered by their positions in the batch.
+	Failures []BatchFailure
+}
+
+// Error implements the error interface.
+func (e *BatchError) Error() string {
+	msgs := make([]string, len(e.Failures))
+	for i, failure := range e.Failures {
+		if failure.ID != "" {
+			msgs[i] = fmt.Sprintf("metric %s: %s", failure.ID, failure.Err)
+		} else {
+			msgs[i] = fmt.Sprintf("item %d: %s", failure.Index, failure.Err)
+		}
+	}
+	return fmt.Sprintf("%d of the Gnocchi metrics batch operations failed: %s",
+		len(e.Failures), strings.Join(msgs, "; "))
+}
+
+// FailedIDs returns IDs of the Gnocchi metrics that failed.
+func (e *BatchError) FailedIDs() []string {
+	var ids []string
+	for _, failure := range e.Failures {
+		if failure.ID != "" {
+			ids = append(ids, failure.ID)
+		}
+	}
+	return ids
+}
+
+// SearchResult represents the result of a search operation. Call its Extract
+// method to interpret it as measures of the found Gnocchi metrics.
+type SearchResult struct {
+	gophercloud.Result
+}
+
+// Extract is a function that accepts a result and extracts matched measures
+// keyed by a metric ID.
+func (r SearchResult) Extract() (map[string][]measures.Measure, error) {
+	var s map[string][]measures.Measure
+	err := r.ExtractInto(&s)
+	return s, err
+}
+
 // Metric is an entity storing aggregates identified by an UUID.
 // It can be attached to a resource using a name.
 // How a metric stores its aggregates is defined by the archive policy
@@ -81,9 +165,35 @@ type Metric struct {
 }
 
 // MetricPage is the page returned by a pager when traversing over a collection
-// of metrics.
+// of metrics. It's paginated with the "limit" and "marker" query parameters.
 type MetricPage struct {
-	pagination.SinglePageBase
+	pagination.MarkerPageBase
+}
+
+// newMetricPage creates a MetricPage that uses itself to find the last marker.
+func newMetricPage(r pagination.PageResult) pagination.Page {
+	p := MetricPage{pagination.MarkerPageBase{PageResult: r}}
+	p.MarkerPageBase.Owner = p
+	return p
+}
+
+// LastMarker returns the ID of the last metric on the page.
+func (r MetricPage) LastMarker() (string, error) {
+	s, err := ExtractMetrics(r)
+	if err != nil || len(s) == 0 {
+		return "", err
+	}
+	return s[len(s)-1].ID, nil
+}
+
+// NextPageURL returns a URL of the next page of metrics. It follows the
+// Gnocchi Link header or uses the last metric ID as a marker.
+func (r MetricPage) NextPageURL() (string, error) {
+	s, err := ExtractMetrics(r)
+	if err != nil {
+		return "", err
+	}
+	return gnocchi.NextPageURL(r.MarkerPageBase, len(s))
 }
 
 // IsEmpty checks whether a MetricPage struct is empty.
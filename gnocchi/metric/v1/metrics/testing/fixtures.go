@@ -1,7 +1,11 @@
 package testing
 
 import (
+	"time"
+
+	"github.com/gophercloud/utils/gnocchi"
 	"github.com/gophercloud/utils/gnocchi/metric/v1/archivepolicies"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/measures"
 	"github.com/gophercloud/utils/gnocchi/metric/v1/metrics"
 )
 
@@ -72,6 +76,79 @@ const MetricsListResult = `[
     }
 ]`
 
+// MetricsListFirstPageResult represents a raw server response to the first
+// page of a list call with the limit of one metric.
+const MetricsListFirstPageResult = `[
+    {
+        "archive_policy": {
+            "aggregation_methods": [
+                "max",
+                "min"
+            ],
+            "back_window": 0,
+            "definition": [
+                {
+                    "granularity": "1:00:00",
+                    "points": 2304,
+                    "timespan": "96 days, 0:00:00"
+                },
+                {
+                    "granularity": "0:05:00",
+                    "points": 9216,
+                    "timespan": "32 days, 0:00:00"
+                },
+                {
+                    "granularity": "1 day, 0:00:00",
+                    "points": 400,
+                    "timespan": "400 days, 0:00:00"
+                }
+            ],
+            "name": "precise"
+        },
+        "created_by_project_id": "e9dc821ca664406e981820a477e9a761",
+        "created_by_user_id": "a23c5b98d42d4df3b961e54d5167eb6d",
+        "creator": "a23c5b98d42d4df3b961e54d5167eb6d:e9dc821ca664406e981820a477e9a761",
+        "id": "777a01d6-4694-49cb-b86a-5ba9fd4e609e",
+        "name": "memory.usage",
+        "resource_id": "1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc",
+        "unit": "MB"
+    }
+]`
+
+// MetricsListSecondPageResult represents a raw server response to the second
+// page of a list call with the limit of one metric.
+const MetricsListSecondPageResult = `[
+    {
+        "archive_policy": {
+            "aggregation_methods": [
+                "mean",
+                "sum"
+            ],
+            "back_window": 12,
+            "definition": [
+                {
+                    "granularity": "1:00:00",
+                    "points": 2160,
+                    "timespan": "90 days, 0:00:00"
+                },
+                {
+                    "granularity": "1 day, 0:00:00",
+                    "points": 200,
+                    "timespan": "200 days, 0:00:00"
+                }
+            ],
+            "name": "not_so_precise"
+        },
+        "created_by_project_id": "c6b68a6b413648b0a0eb191bf3222f4d",
+        "created_by_user_id": "cb072aacdb494419aeeba5f1c62d1a65",
+        "creator": "cb072aacdb494419aeeba5f1c62d1a65:c6b68a6b413648b0a0eb191bf3222f4d",
+        "id": "6dbc97c5-bfdf-47a2-b184-02e7fa348d21",
+        "name": "cpu.delta",
+        "resource_id": "c5dc0c47-f43c-425c-a82f-44d61ee91175",
+        "unit": "ns"
+    }
+]`
+
 // Metric1 is an expected representation of a first metric from the MetricsListResult.
 var Metric1 = metrics.Metric{
 	ArchivePolicy: archivepolicies.ArchivePolicy{
@@ -82,19 +159,19 @@ var Metric1 = metrics.Metric{
 		BackWindow: 0,
 		Definition: []archivepolicies.ArchivePolicyDefinition{
 			{
-				Granularity: "1:00:00",
+				Granularity: gnocchi.Duration(time.Hour),
 				Points:      2304,
-				TimeSpan:    "96 days, 0:00:00",
+				TimeSpan:    gnocchi.Duration(96 * 24 * time.Hour),
 			},
 			{
-				Granularity: "0:05:00",
+				Granularity: gnocchi.Duration(5 * time.Minute),
 				Points:      9216,
-				TimeSpan:    "32 days, 0:00:00",
+				TimeSpan:    gnocchi.Duration(32 * 24 * time.Hour),
 			},
 			{
-				Granularity: "1 day, 0:00:00",
+				Granularity: gnocchi.Duration(24 * time.Hour),
 				Points:      400,
-				TimeSpan:    "400 days, 0:00:00",
+				TimeSpan:    gnocchi.Duration(400 * 24 * time.Hour),
 			},
 		},
 		Name: "precise",
@@ -118,14 +195,14 @@ var Metric2 = metrics.Metric{
 		BackWindow: 12,
 		Definition: []archivepolicies.ArchivePolicyDefinition{
 			{
-				Granularity: "1:00:00",
+				Granularity: gnocchi.Duration(time.Hour),
 				Points:      2160,
-				TimeSpan:    "90 days, 0:00:00",
+				TimeSpan:    gnocchi.Duration(90 * 24 * time.Hour),
 			},
 			{
-				Granularity: "1 day, 0:00:00",
+				Granularity: gnocchi.Duration(24 * time.Hour),
 				Points:      200,
-				TimeSpan:    "200 days, 0:00:00",
+				TimeSpan:    gnocchi.Duration(200 * 24 * time.Hour),
 			},
 		},
 		Name: "not_so_precise",
@@ -208,3 +285,140 @@ const MetricCreateResponse = `
     "unit": "B/s"
 }
 `
+
+// MetricSearchRequest represents a raw search request.
+const MetricSearchRequest = `
+{
+    "or": [
+        {
+            "gt": 80
+        },
+        {
+            "and": [
+                {
+                    "ge": 5
+                },
+                {
+                    "le": 10
+                }
+            ]
+        }
+    ]
+}
+`
+
+// MetricSearchResult represents a raw server response from a server to a search request.
+const MetricSearchResult = `
+{
+    "01b2953e-de74-448a-a305-c84440697933": [
+        [
+            "2018-01-10T12:00:00+00:00",
+            3600.0,
+            91.5
+        ]
+    ],
+    "9e5a6441-1044-4181-b66e-34e180753040": [
+        [
+            "2018-01-10T12:00:00+00:00",
+            3600.0,
+            7.0
+        ],
+        [
+            "2018-01-10T13:00:00+00:00",
+            3600.0,
+            82.0
+        ]
+    ]
+}
+`
+
+// SearchMetricsExpected represents an expected response from a search request.
+var SearchMetricsExpected = map[string][]measures.Measure{
+	"01b2953e-de74-448a-a305-c84440697933": {
+		{
+			Timestamp:   time.Date(2018, 1, 10, 12, 0, 0, 0, time.UTC),
+			Granularity: 3600.0,
+			Value:       91.5,
+		},
+	},
+	"9e5a6441-1044-4181-b66e-34e180753040": {
+		{
+			Timestamp:   time.Date(2018, 1, 10, 12, 0, 0, 0, time.UTC),
+			Granularity: 3600.0,
+			Value:       7.0,
+		},
+		{
+			Timestamp:   time.Date(2018, 1, 10, 13, 0, 0, 0, time.UTC),
+			Granularity: 3600.0,
+			Value:       82.0,
+		},
+	},
+}
+
+// MetricUpdateRequest represents a request to update a metric.
+const MetricUpdateRequest = `
+{
+    "archive_policy_name": "low",
+    "unit": "KB"
+}
+`
+
+// MetricUpdateResponse represents a raw server response to the MetricUpdateRequest.
+const MetricUpdateResponse = `
+{
+    "archive_policy": {
+        "aggregation_methods": [
+            "mean"
+        ],
+        "back_window": 0,
+        "definition": [
+            {
+                "granularity": "0:05:00",
+                "points": 12,
+                "timespan": "1:00:00"
+            }
+        ],
+        "name": "low"
+    },
+    "created_by_project_id": "e9dc821ca664406e981820a477e9a761",
+    "created_by_user_id": "a23c5b98d42d4df3b961e54d5167eb6d",
+    "creator": "a23c5b98d42d4df3b961e54d5167eb6d:e9dc821ca664406e981820a477e9a761",
+    "id": "777a01d6-4694-49cb-b86a-5ba9fd4e609e",
+    "name": "memory.usage",
+    "resource_id": "1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc",
+    "unit": "KB"
+}
+`
+
+// MetricMigrateRequest represents a request to move a metric to another archive policy.
+const MetricMigrateRequest = `
+{
+    "archive_policy_name": "low"
+}
+`
+
+// MetricLowPolicyGetResult represents a raw server response to a get request
+// of a metric that uses the "low" archive policy.
+const MetricLowPolicyGetResult = `
+{
+    "archive_policy": {
+        "name": "low"
+    },
+    "id": "01b2953e-de74-448a-a305-c84440697933",
+    "name": "network.incoming.bytes.rate",
+    "unit": "B/s"
+}
+`
+
+// MetricPreciseGetResult represents a raw server response to a get request
+// of a metric that uses the "precise" archive policy.
+const MetricPreciseGetResult = `
+{
+    "archive_policy": {
+        "name": "precise"
+    },
+    "id": "777a01d6-4694-49cb-b86a-5ba9fd4e609e",
+    "name": "memory.usage",
+    "unit": "MB"
+}
+`
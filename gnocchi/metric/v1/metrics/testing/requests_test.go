@@ -1,13 +1,18 @@
 package testing
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/pagination"
 	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/utils/gnocchi"
 	"github.com/gophercloud/utils/gnocchi/metric/v1/archivepolicies"
 	"github.com/gophercloud/utils/gnocchi/metric/v1/metrics"
 	"github.com/gophercloud/utils/gnocchi/metric/v1/resources"
@@ -53,6 +58,39 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestListWithMarker(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/metric", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		r.ParseForm()
+		marker := r.Form.Get("marker")
+		switch marker {
+		case "":
+			fmt.Fprintf(w, MetricsListFirstPageResult)
+		case "777a01d6-4694-49cb-b86a-5ba9fd4e609e":
+			fmt.Fprintf(w, MetricsListSecondPageResult)
+		case "6dbc97c5-bfdf-47a2-b184-02e7fa348d21":
+			fmt.Fprintf(w, `[]`)
+		default:
+			t.Fatalf("Unexpected marker: [%s]", marker)
+		}
+	})
+
+	allPages, err := metrics.List(fake.ServiceClient(), metrics.ListOpts{Limit: 1}).AllPages()
+	th.AssertNoErr(t, err)
+
+	actual, err := metrics.ExtractMetrics(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []metrics.Metric{Metric1, Metric2}, actual)
+}
+
 func TestGet(t *testing.T) {
 	th.SetupHTTP()
 	defer th.TeardownHTTP()
@@ -78,14 +116,14 @@ func TestGet(t *testing.T) {
 		BackWindow: 12,
 		Definition: []archivepolicies.ArchivePolicyDefinition{
 			{
-				Granularity: "1:00:00",
+				Granularity: gnocchi.Duration(time.Hour),
 				Points:      2160,
-				TimeSpan:    "90 days, 0:00:00",
+				TimeSpan:    gnocchi.Duration(90 * 24 * time.Hour),
 			},
 			{
-				Granularity: "1 day, 0:00:00",
+				Granularity: gnocchi.Duration(24 * time.Hour),
 				Points:      200,
-				TimeSpan:    "200 days, 0:00:00",
+				TimeSpan:    gnocchi.Duration(200 * 24 * time.Hour),
 			},
 		},
 		Name: "not_so_precise",
@@ -149,6 +187,223 @@ func TestCreate(t *testing.T) {
 	th.AssertEquals(t, s.Unit, "B/s")
 }
 
+func TestUpdate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/metric/777a01d6-4694-49cb-b86a-5ba9fd4e609e", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "PATCH")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestHeader(t, r, "Accept", "application/json")
+		th.TestJSONRequest(t, r, MetricUpdateRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprintf(w, MetricUpdateResponse)
+	})
+
+	archivePolicyName := "low"
+	unit := "KB"
+	opts := metrics.UpdateOpts{
+		ArchivePolicyName: &archivePolicyName,
+		Unit:              &unit,
+	}
+	s, err := metrics.Update(fake.ServiceClient(), "777a01d6-4694-49cb-b86a-5ba9fd4e609e", opts).Extract()
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, s.ArchivePolicy.Name, "low")
+	th.AssertEquals(t, s.ID, "777a01d6-4694-49cb-b86a-5ba9fd4e609e")
+	th.AssertEquals(t, s.Unit, "KB")
+}
+
+func TestUpdateWithoutChanges(t *testing.T) {
+	res := metrics.Update(fake.ServiceClient(), "777a01d6-4694-49cb-b86a-5ba9fd4e609e", metrics.UpdateOpts{})
+	if res.Err == nil {
+		t.Fatal("Expected an error for UpdateOpts without changes")
+	}
+}
+
+func TestMigrateArchivePolicy(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	// The first metric uses the "precise" archive policy and needs to be migrated.
+	th.Mux.HandleFunc("/v1/metric/777a01d6-4694-49cb-b86a-5ba9fd4e609e", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		switch r.Method {
+		case "GET":
+			fmt.Fprintf(w, MetricPreciseGetResult)
+		case "PATCH":
+			th.TestJSONRequest(t, r, MetricMigrateRequest)
+			fmt.Fprintf(w, MetricUpdateResponse)
+		default:
+			t.Fatalf("Unexpected method: %s", r.Method)
+		}
+	})
+
+	// The second metric uses the "not_so_precise" archive policy.
+	th.Mux.HandleFunc("/v1/metric/0ddf61cf-3747-4f75-bf13-13c28ff03ae3", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprintf(w, MetricGetResult)
+	})
+
+	// The third metric already uses the "low" archive policy.
+	th.Mux.HandleFunc("/v1/metric/01b2953e-de74-448a-a305-c84440697933", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprintf(w, MetricLowPolicyGetResult)
+	})
+
+	metricIDs := []string{
+		"777a01d6-4694-49cb-b86a-5ba9fd4e609e",
+		"0ddf61cf-3747-4f75-bf13-13c28ff03ae3",
+		"01b2953e-de74-448a-a305-c84440697933",
+	}
+	from := archivepolicies.ArchivePolicy{Name: "precise"}
+	to := archivepolicies.ArchivePolicy{Name: "low"}
+	results := metrics.MigrateArchivePolicy(fake.ServiceClient(), metricIDs, from, to)
+	th.AssertEquals(t, 3, len(results))
+
+	th.AssertEquals(t, "777a01d6-4694-49cb-b86a-5ba9fd4e609e", results[0].MetricID)
+	th.AssertNoErr(t, results[0].Err)
+	th.AssertEquals(t, "low", results[0].Metric.ArchivePolicy.Name)
+
+	th.AssertEquals(t, "0ddf61cf-3747-4f75-bf13-13c28ff03ae3", results[1].MetricID)
+	if results[1].Err == nil {
+		t.Fatal("Expected an error for a metric with another archive policy")
+	}
+	if results[1].Metric != nil {
+		t.Fatal("Expected no metric for a failed migration")
+	}
+
+	th.AssertEquals(t, "01b2953e-de74-448a-a305-c84440697933", results[2].MetricID)
+	th.AssertNoErr(t, results[2].Err)
+	th.AssertEquals(t, "low", results[2].Metric.ArchivePolicy.Name)
+}
+
+func TestBatchCreate(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	th.Mux.HandleFunc("/v1/metric", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		defer func() {
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}()
+
+		var opts struct {
+			Name string `json:"name"`
+		}
+		th.AssertNoErr(t, json.NewDecoder(r.Body).Decode(&opts))
+
+		w.Header().Add("Content-Type", "application/json")
+		if opts.Name == "disk.usage" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"description": "Unknown archive policy"}`)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"id": "%s-id", "name": "%s"}`, opts.Name, opts.Name)
+	})
+
+	opts := []metrics.CreateOptsBuilder{
+		metrics.CreateOpts{Name: "cpu.util"},
+		metrics.CreateOpts{Name: "disk.usage"},
+		metrics.CreateOpts{Name: "memory.usage"},
+		metrics.CreateOpts{Name: "network.incoming.bytes.rate"},
+	}
+	created, err := metrics.BatchCreate(fake.ServiceClient(), opts, 2)
+
+	batchErr, ok := err.(*metrics.BatchError)
+	if !ok {
+		t.Fatalf("Expected a *metrics.BatchError, got %v", err)
+	}
+	th.AssertEquals(t, 1, len(batchErr.Failures))
+	th.AssertEquals(t, 1, batchErr.Failures[0].Index)
+	th.AssertEquals(t, "", batchErr.Failures[0].ID)
+
+	th.AssertEquals(t, 4, len(created))
+	th.AssertEquals(t, "cpu.util-id", created[0].ID)
+	if created[1] != nil {
+		t.Fatalf("Expected no metric for a failed item, got %v", created[1])
+	}
+	th.AssertEquals(t, "memory.usage-id", created[2].ID)
+	th.AssertEquals(t, "network.incoming.bytes.rate-id", created[3].ID)
+
+	if maxRunning > 2 {
+		t.Fatalf("Expected no more than 2 concurrent requests, got %d", maxRunning)
+	}
+}
+
+func TestBatchDelete(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	metricIDs := []string{
+		"01b2953e-de74-448a-a305-c84440697933",
+		"777a01d6-4694-49cb-b86a-5ba9fd4e609e",
+		"6dbc97c5-bfdf-47a2-b184-02e7fa348d21",
+	}
+	for i, metricID := range metricIDs {
+		status := http.StatusNoContent
+		if i == 1 {
+			status = http.StatusNotFound
+		}
+		th.Mux.HandleFunc("/v1/metric/"+metricID, func(w http.ResponseWriter, r *http.Request) {
+			th.TestMethod(t, r, "DELETE")
+			th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+			w.WriteHeader(status)
+		})
+	}
+
+	err := metrics.BatchDelete(fake.ServiceClient(), metricIDs, 0)
+
+	batchErr, ok := err.(*metrics.BatchError)
+	if !ok {
+		t.Fatalf("Expected a *metrics.BatchError, got %v", err)
+	}
+	th.CheckDeepEquals(t, []string{"777a01d6-4694-49cb-b86a-5ba9fd4e609e"}, batchErr.FailedIDs())
+	if _, ok := batchErr.Failures[0].Err.(gophercloud.ErrDefault404); !ok {
+		t.Fatalf("Expected the 404 error, got %v", batchErr.Failures[0].Err)
+	}
+}
+
+func TestBatchDeleteSucceeded(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/metric/01b2953e-de74-448a-a305-c84440697933", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	err := metrics.BatchDelete(fake.ServiceClient(), []string{"01b2953e-de74-448a-a305-c84440697933"}, 1)
+	th.AssertNoErr(t, err)
+}
+
 func TestDelete(t *testing.T) {
 	th.SetupHTTP()
 	defer th.TeardownHTTP()
@@ -162,3 +417,45 @@ func TestDelete(t *testing.T) {
 	res := metrics.Delete(fake.ServiceClient(), "01b2953e-de74-448a-a305-c84440697933")
 	th.AssertNoErr(t, res.Err)
 }
+
+func TestSearch(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/search/metric", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestJSONRequest(t, r, MetricSearchRequest)
+		th.CheckDeepEquals(t, url.Values{
+			"metric_id": []string{
+				"01b2953e-de74-448a-a305-c84440697933",
+				"9e5a6441-1044-4181-b66e-34e180753040",
+			},
+			"start":       []string{"2018-01-10T12:00:00"},
+			"granularity": []string{"3600"},
+		}, r.URL.Query())
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprintf(w, MetricSearchResult)
+	})
+
+	startTime := time.Date(2018, 1, 10, 12, 0, 0, 0, time.UTC)
+	opts := metrics.SearchOpts{
+		MetricIDs: []string{
+			"01b2953e-de74-448a-a305-c84440697933",
+			"9e5a6441-1044-4181-b66e-34e180753040",
+		},
+		Query: metrics.Or(
+			metrics.Gt(80),
+			metrics.And(metrics.Ge(5), metrics.Le(10)),
+		),
+		Start:       &startTime,
+		Granularity: gnocchi.Duration(time.Hour),
+	}
+	actual, err := metrics.Search(fake.ServiceClient(), opts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, SearchMetricsExpected, actual)
+}
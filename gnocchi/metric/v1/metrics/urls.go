@@ -2,7 +2,10 @@ package metrics
 
 import "github.com/gophercloud/gophercloud"
 
-const resourcePath = "metric"
+const (
+	resourcePath = "metric"
+	searchPath   = "search/metric"
+)
 
 func resourceURL(c *gophercloud.ServiceClient, metricID string) string {
 	return c.ServiceURL(resourcePath, metricID)
@@ -24,6 +27,14 @@ func createURL(c *gophercloud.ServiceClient) string {
 	return rootURL(c)
 }
 
+func updateURL(c *gophercloud.ServiceClient, metricID string) string {
+	return resourceURL(c, metricID)
+}
+
 func deleteURL(c *gophercloud.ServiceClient, metricID string) string {
 	return resourceURL(c, metricID)
 }
+
+func searchURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL(searchPath)
+}
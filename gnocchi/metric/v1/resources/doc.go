@@ -22,6 +22,53 @@ Example of Listing resources
 		fmt.Printf("%+v\n", resource)
 	}
 
+Example of Searching resources with a query
+
+	searchOpts := resources.SearchOpts{
+		Query: resources.And(
+			resources.Eq("flavor_id", "2"),
+			resources.Like("host", "compute%"),
+			resources.Not(resources.In("project_id", "4154f08883334e0494c41155c33c0fc9", "3d40ca37723449118987b9f288f4ae84")),
+		),
+		Details: true,
+		Limit:   100,
+		Sort:    []string{"started_at:desc"},
+	}
+	resourceType := "instance"
+	foundResources, err := resources.Search(gnocchiClient, resourceType, searchOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example of Searching all resources page by page
+
+	searchOpts := resources.SearchOpts{
+		Query:   resources.Eq("flavor_id", "2"),
+		Details: true,
+		Limit:   100,
+	}
+	resourceType := "instance"
+	err := resources.EachSearchPage(gnocchiClient, resourceType, searchOpts, func(page []resources.Resource) (bool, error) {
+		for _, resource := range page {
+			fmt.Printf("%+v\n", resource)
+		}
+		return true, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+
+Example of Searching resources with a string filter
+
+	searchOpts := resources.SearchOpts{
+		Filter: "flavor_id='2' and host like 'compute%'",
+	}
+	resourceType := "instance"
+	foundResources, err := resources.Search(gnocchiClient, resourceType, searchOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
 Example of Getting a resource
 
 	resourceID = "23d5d3f7-9dfa-4f73-b72b-8b0b0063ec55"
@@ -31,6 +78,40 @@ Example of Getting a resource
 		panic(err)
 	}
 
+Example of Decoding extra attributes of a resource into a struct
+
+	var attributes struct {
+		DisplayName string `json:"display_name"`
+		FlavorID    string `json:"flavor_id"`
+	}
+	err := resource.ExtractExtraAttributesInto(&attributes)
+	if err != nil {
+		panic(err)
+	}
+
+Example of Listing revisions of a resource
+
+	resourceType := "compute_instance"
+	resourceID := "1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc"
+	historyOpts := resources.HistoryOpts{
+		Details: true,
+		Sort:    []string{"revision_start:desc"},
+	}
+
+	allPages, err := resources.History(gnocchiClient, resourceType, resourceID, historyOpts).AllPages()
+	if err != nil {
+		panic(err)
+	}
+
+	allRevisions, err := resources.ExtractResources(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, revision := range allRevisions {
+		fmt.Printf("%s - %s: %+v\n", revision.RevisionStart, revision.RevisionEnd, revision.ExtraAttributes)
+	}
+
 Example of Creating a resource without a metric
 
 	createOpts := resources.CreateOpts{
@@ -78,6 +159,27 @@ Example of Creating a resource and a metric a the same time
 		panic(err)
 	}
 
+Example of Validating extra attributes of a resource against its resource type
+
+	resourceType, err := resourcetypes.Get(gnocchiClient, "compute_instance").Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	createOpts := resources.CreateOpts{
+		ID: "23d5d3f7-9dfa-4f73-b72b-8b0b0063ec55",
+		ExtraAttributes: map[string]interface{}{
+			"display_name": "test-instance",
+			"flavor_id":    "2",
+			"host":         "compute-1",
+			"image_ref":    "4d8f5b8b-e4f6-4d18-8d5f-8e4b0e4c3a5b",
+		},
+	}
+	err = createOpts.Validate(*resourceType)
+	if err != nil {
+		panic(err)
+	}
+
 Example of Updating a resource
 
 	updateOpts := resources.UpdateOpts{
@@ -124,6 +226,47 @@ Example of Updating a resource and creating an associated metric at the same tim
 		panic(err)
 	}
 
+Example of Listing metrics of a resource
+
+	resourceType := "compute_instance"
+	resourceID := "1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc"
+	allPages, err := resources.ListMetrics(gnocchiClient, resourceType, resourceID).AllPages()
+	if err != nil {
+		panic(err)
+	}
+
+	allMetrics, err := resources.ExtractMetrics(allPages)
+	if err != nil {
+		panic(err)
+	}
+
+Example of Adding metrics to a resource
+
+	addMetricsOpts := resources.AddMetricsOpts{
+		Metrics: map[string]interface{}{
+			"cpu_util": map[string]string{
+				"archive_policy_name": "medium",
+				"unit":                "%",
+			},
+			"disk.write.bytes.rate": "0a2da84d-4753-43f5-a65f-0f8d44d2766c",
+		},
+	}
+	resourceType := "compute_instance"
+	resourceID := "1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc"
+	allMetrics, err := resources.AddMetrics(gnocchiClient, resourceType, resourceID, addMetricsOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+Example of Removing a metric from a resource
+
+	resourceType := "compute_instance"
+	resourceID := "1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc"
+	err := resources.RemoveMetric(gnocchiClient, resourceType, resourceID, "cpu_util").ExtractErr()
+	if err != nil {
+		panic(err)
+	}
+
 Example of Deleting a Gnocchi resource
 
 	resourceID := "23d5d3f7-9dfa-4f73-b72b-8b0b0063ec55"
@@ -131,5 +274,17 @@ Example of Deleting a Gnocchi resource
 	if err != nil {
 		panic(err)
 	}
+
+Example of Deleting Gnocchi resources that match a query
+
+	query := resources.And(
+		resources.Ne("ended_at", nil),
+		resources.Lt("ended_at", "2018-01-01T00:00:00"),
+	)
+	resourceType := "instance"
+	deleted, err := resources.BatchDelete(gnocchiClient, resourceType, query).Extract()
+	if err != nil {
+		panic(err)
+	}
 */
 package resources
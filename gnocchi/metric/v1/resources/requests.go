@@ -1,6 +1,7 @@
 package resources
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/gophercloud/gophercloud"
@@ -52,9 +53,177 @@ func List(c *gophercloud.ServiceClient, opts ListOptsBuilder, resourceType strin
 		}
 		url += query
 	}
-	return pagination.NewPager(c, url, func(r pagination.PageResult) pagination.Page {
-		return ResourcePage{pagination.SinglePageBase(r)}
+	return pagination.NewPager(c, url, newResourcePage)
+}
+
+// Query represents a Gnocchi search filter in the JSON filter syntax.
+// It can be built with the Eq, Ne, Lt, Le, Gt, Ge, In, Like, And, Or and Not functions.
+type Query map[string]interface{}
+
+// Eq builds a Query that matches resources whose attribute equals the value.
+func Eq(attribute string, value interface{}) Query {
+	return Query{"eq": map[string]interface{}{attribute: value}}
+}
+
+// Ne builds a Query that matches resources whose attribute doesn't equal the value.
+func Ne(attribute string, value interface{}) Query {
+	return Query{"ne": map[string]interface{}{attribute: value}}
+}
+
+// Lt builds a Query that matches resources whose attribute is less than the value.
+func Lt(attribute string, value interface{}) Query {
+	return Query{"lt": map[string]interface{}{attribute: value}}
+}
+
+// Le builds a Query that matches resources whose attribute is less than or equal to the value.
+func Le(attribute string, value interface{}) Query {
+	return Query{"le": map[string]interface{}{attribute: value}}
+}
+
+// Gt builds a Query that matches resources whose attribute is greater than the value.
+func Gt(attribute string, value interface{}) Query {
+	return Query{"gt": map[string]interface{}{attribute: value}}
+}
+
+// Ge builds a Query that matches resources whose attribute is greater than or equal to the value.
+func Ge(attribute string, value interface{}) Query {
+	return Query{"ge": map[string]interface{}{attribute: value}}
+}
+
+// In builds a Query that matches resources whose attribute equals one of the values.
+func In(attribute string, values ...interface{}) Query {
+	return Query{"in": map[string]interface{}{attribute: values}}
+}
+
+// Like builds a Query that matches resources whose attribute matches the SQL LIKE pattern.
+func Like(attribute, pattern string) Query {
+	return Query{"like": map[string]interface{}{attribute: pattern}}
+}
+
+// And builds a Query that matches resources satisfying all of the provided queries.
+func And(queries ...Query) Query {
+	return Query{"and": queries}
+}
+
+// Or builds a Query that matches resources satisfying any of the provided queries.
+func Or(queries ...Query) Query {
+	return Query{"or": queries}
+}
+
+// Not builds a Query that matches resources that don't satisfy the provided query.
+func Not(query Query) Query {
+	return Query{"not": query}
+}
+
+// SearchOptsBuilder allows extensions to add additional parameters to the
+// Search request.
+type SearchOptsBuilder interface {
+	// ToResourceSearchMap builds a request body.
+	ToResourceSearchMap() (map[string]interface{}, error)
+
+	// ToResourceSearchQuery builds a query string.
+	ToResourceSearchQuery() (string, error)
+}
+
+// SearchOpts specifies parameters of the Gnocchi resources Search request.
+type SearchOpts struct {
+	// Query is a search filter in the JSON filter syntax.
+	Query Query
+
+	// Filter is a search filter in the string syntax, for example
+	// "flavor_id='2' and host like 'compute%'".
+	// It can't be used together with the Query.
+	Filter string `q:"filter"`
+
+	// Details allows to search resources with all attributes.
+	Details bool `q:"details"`
+
+	// Limit allows to limits count of resources in the response.
+	Limit int `q:"limit"`
+
+	// Marker is used for pagination.
+	Marker string `q:"marker"`
+
+	// Sort allows to sort resources in the response by keys.
+	// Every key can have a direction suffix, for example "started_at:desc".
+	Sort []string `q:"sort"`
+}
+
+// ToResourceSearchMap constructs a request body from SearchOpts.
+func (opts SearchOpts) ToResourceSearchMap() (map[string]interface{}, error) {
+	if opts.Query != nil && opts.Filter != "" {
+		return nil, fmt.Errorf("only one of the SearchOpts 'Query' and 'Filter' arguments can be provided")
+	}
+	if opts.Query == nil {
+		return map[string]interface{}{}, nil
+	}
+	return opts.Query, nil
+}
+
+// ToResourceSearchQuery formats a SearchOpts into a query string.
+func (opts SearchOpts) ToResourceSearchQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	return q.String(), err
+}
+
+// Search requests Gnocchi resources of the specified type that match
+// the provided search filter. It returns a single page of resources that is
+// limited by the Limit option or by the server maximum, use EachSearchPage to
+// read all found resources.
+func Search(c *gophercloud.ServiceClient, resourceType string, opts SearchOptsBuilder) (r SearchResult) {
+	url := searchURL(c, resourceType)
+	query, err := opts.ToResourceSearchQuery()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	url += query
+
+	b, err := opts.ToResourceSearchMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+
+	resp, err := c.Post(url, b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
 	})
+	if resp != nil {
+		r.Header = resp.Header
+	}
+	r.Err = err
+	return
+}
+
+// EachSearchPage searches Gnocchi resources of the specified type page by page
+// and calls the provided handler for every page. The next page is requested
+// with the ID of the last found resource as the marker while the server
+// reports the next page with the "Link" header or the page is full.
+// Return "false" from the handler to prematurely stop iterating.
+func EachSearchPage(c *gophercloud.ServiceClient, resourceType string, opts SearchOpts, handler func([]Resource) (bool, error)) error {
+	for {
+		r := Search(c, resourceType, opts)
+		page, err := r.Extract()
+		if err != nil {
+			return err
+		}
+		if len(page) == 0 {
+			return nil
+		}
+
+		ok, err := handler(page)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+
+		if gnocchi.NextLink(r.Header) == "" && (opts.Limit == 0 || len(page) < opts.Limit) {
+			return nil
+		}
+		opts.Marker = page[len(page)-1].ID
+	}
 }
 
 // Get retrieves a specific Gnocchi resource based on its type and ID.
@@ -63,6 +232,50 @@ func Get(c *gophercloud.ServiceClient, resourceType string, resourceID string) (
 	return
 }
 
+// HistoryOptsBuilder allows extensions to add additional parameters to the
+// History request.
+type HistoryOptsBuilder interface {
+	ToResourceHistoryQuery() (string, error)
+}
+
+// HistoryOpts allows the limiting and sorting of resource revisions through
+// the Gnocchi API.
+type HistoryOpts struct {
+	// Details allows to list resource revisions with all attributes.
+	Details bool `q:"details"`
+
+	// Limit allows to limits count of revisions in the response.
+	Limit int `q:"limit"`
+
+	// Marker is used for pagination.
+	Marker string `q:"marker"`
+
+	// Sort allows to sort revisions in the response by keys.
+	// Every key can have a direction suffix, for example "revision_start:desc".
+	Sort []string `q:"sort"`
+}
+
+// ToResourceHistoryQuery formats a HistoryOpts into a query string.
+func (opts HistoryOpts) ToResourceHistoryQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	return q.String(), err
+}
+
+// History returns a Pager which allows you to iterate over all revisions of
+// a specific Gnocchi resource. Every revision is represented as a Resource
+// with its RevisionStart and RevisionEnd timestamps.
+func History(c *gophercloud.ServiceClient, resourceType, resourceID string, opts HistoryOptsBuilder) pagination.Pager {
+	url := historyURL(c, resourceType, resourceID)
+	if opts != nil {
+		query, err := opts.ToResourceHistoryQuery()
+		if err != nil {
+			return pagination.Pager{Err: err}
+		}
+		url += query
+	}
+	return pagination.NewPager(c, url, newResourceHistoryPage)
+}
+
 // CreateOptsBuilder allows to add additional parameters to the
 // Create request.
 type CreateOptsBuilder interface {
@@ -211,3 +424,90 @@ func Delete(c *gophercloud.ServiceClient, resourceType, resourceID string) (r De
 	_, r.Err = c.Delete(deleteURL(c, resourceType, resourceID), requestOpts)
 	return
 }
+
+// BatchDeleteOptsBuilder allows extensions to add additional parameters to the
+// BatchDelete request.
+type BatchDeleteOptsBuilder interface {
+	ToResourceBatchDeleteMap() (map[string]interface{}, error)
+}
+
+// ToResourceBatchDeleteMap constructs a BatchDelete request body from a Query.
+func (q Query) ToResourceBatchDeleteMap() (map[string]interface{}, error) {
+	// An empty filter makes Gnocchi delete all resources of the type.
+	if len(q) == 0 {
+		return nil, fmt.Errorf("provided Gnocchi resources BatchDelete query is empty")
+	}
+	return q, nil
+}
+
+// BatchDelete deletes all Gnocchi resources of the specified type that match
+// the provided search filter.
+func BatchDelete(c *gophercloud.ServiceClient, resourceType string, opts BatchDeleteOptsBuilder) (r BatchDeleteResult) {
+	b, err := opts.ToResourceBatchDeleteMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = c.Request("DELETE", batchDeleteURL(c, resourceType), &gophercloud.RequestOpts{
+		JSONBody:     b,
+		JSONResponse: &r.Body,
+		OkCodes:      []int{200},
+	})
+	return
+}
+
+// ListMetrics returns a Pager which allows you to iterate over a collection of
+// metrics attached to a specific Gnocchi resource.
+func ListMetrics(c *gophercloud.ServiceClient, resourceType, resourceID string) pagination.Pager {
+	return pagination.NewPager(c, listMetricsURL(c, resourceType, resourceID), func(r pagination.PageResult) pagination.Page {
+		return MetricPage{pagination.SinglePageBase(r)}
+	})
+}
+
+// AddMetricsOptsBuilder allows extensions to add additional parameters to the
+// AddMetrics request.
+type AddMetricsOptsBuilder interface {
+	ToResourceAddMetricsMap() (map[string]interface{}, error)
+}
+
+// AddMetricsOpts specifies metrics that need to be attached to a Gnocchi resource.
+type AddMetricsOpts struct {
+	// Metrics field can be used to link existing metrics in the resource
+	// or to create metrics and attach them to the resource at the same time.
+	// It's keyed by a metric name.
+	Metrics map[string]interface{} `json:"-" required:"true"`
+}
+
+// ToResourceAddMetricsMap constructs a request body from AddMetricsOpts.
+func (opts AddMetricsOpts) ToResourceAddMetricsMap() (map[string]interface{}, error) {
+	if len(opts.Metrics) == 0 {
+		return nil, fmt.Errorf("missing input for the AddMetricsOpts 'Metrics' argument")
+	}
+	return opts.Metrics, nil
+}
+
+// AddMetrics attaches new or existing metrics to a specific Gnocchi resource
+// by their names.
+func AddMetrics(c *gophercloud.ServiceClient, resourceType, resourceID string, opts AddMetricsOptsBuilder) (r AddMetricsResult) {
+	b, err := opts.ToResourceAddMetricsMap()
+	if err != nil {
+		r.Err = err
+		return
+	}
+	_, r.Err = c.Post(addMetricsURL(c, resourceType, resourceID), b, &r.Body, &gophercloud.RequestOpts{
+		OkCodes: []int{200},
+	})
+	return
+}
+
+// RemoveMetric detaches a metric with the provided name from a specific
+// Gnocchi resource and deletes it.
+func RemoveMetric(c *gophercloud.ServiceClient, resourceType, resourceID, metricName string) (r RemoveMetricResult) {
+	requestOpts := &gophercloud.RequestOpts{
+		MoreHeaders: map[string]string{
+			"Accept": "application/json, */*",
+		},
+	}
+	_, r.Err = c.Delete(removeMetricURL(c, resourceType, resourceID, metricName), requestOpts)
+	return
+}
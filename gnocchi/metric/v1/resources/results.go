@@ -7,6 +7,7 @@ import (
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/pagination"
 	"github.com/gophercloud/utils/gnocchi"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/archivepolicies"
 	"github.com/gophercloud/utils/internal"
 )
 
@@ -45,6 +46,35 @@ type DeleteResult struct {
 	gophercloud.ErrResult
 }
 
+// BatchDeleteResult represents the result of a batch delete operation. Call its
+// Extract method to get a count of deleted Gnocchi resources.
+type BatchDeleteResult struct {
+	gophercloud.Result
+}
+
+// Extract is a function that accepts a result and extracts a count of deleted
+// Gnocchi resources.
+func (r BatchDeleteResult) Extract() (int, error) {
+	var s struct {
+		Deleted int `json:"deleted"`
+	}
+	err := r.ExtractInto(&s)
+	return s.Deleted, err
+}
+
+// SearchResult represents the result of a search operation. Call its Extract
+// method to interpret it as a slice of Gnocchi resources.
+type SearchResult struct {
+	gophercloud.Result
+}
+
+// Extract is a function that accepts a result and extracts found Gnocchi resources.
+func (r SearchResult) Extract() ([]Resource, error) {
+	var s []Resource
+	err := r.ExtractInto(&s)
+	return s, err
+}
+
 // Resource is an entity representing anything in your infrastructure
 // that you will associate metric(s) with.
 // It is identified by a unique ID and can contain attributes.
@@ -143,6 +173,16 @@ func (r *Resource) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+// ExtractExtraAttributesInto decodes extra attributes of the resource into
+// the provided value, usually a pointer to a struct with json tags.
+func (r Resource) ExtractExtraAttributesInto(to interface{}) error {
+	b, err := json.Marshal(r.ExtraAttributes)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, to)
+}
+
 // ResourcePage abstracts the raw results of making a List() request against
 // the Gnocchi API.
 //
@@ -150,7 +190,48 @@ func (r *Resource) UnmarshalJSON(b []byte) error {
 // returned to the client, you may only safely access the data provided through
 // the ExtractResources call.
 type ResourcePage struct {
-	pagination.SinglePageBase
+	pagination.MarkerPageBase
+
+	// history is set for pages of the History request. Their markers contain
+	// resource revisions so they can only be followed via the Link header.
+	history bool
+}
+
+// newResourcePage creates a ResourcePage that uses itself to find the last marker.
+func newResourcePage(r pagination.PageResult) pagination.Page {
+	p := ResourcePage{MarkerPageBase: pagination.MarkerPageBase{PageResult: r}}
+	p.MarkerPageBase.Owner = p
+	return p
+}
+
+// newResourceHistoryPage creates a ResourcePage of the resource history.
+func newResourceHistoryPage(r pagination.PageResult) pagination.Page {
+	p := ResourcePage{MarkerPageBase: pagination.MarkerPageBase{PageResult: r}, history: true}
+	p.MarkerPageBase.Owner = p
+	return p
+}
+
+// LastMarker returns the ID of the last resource on the page.
+func (r ResourcePage) LastMarker() (string, error) {
+	s, err := ExtractResources(r)
+	if err != nil || len(s) == 0 {
+		return "", err
+	}
+	return s[len(s)-1].ID, nil
+}
+
+// NextPageURL returns a URL of the next page of resources. It follows the
+// Gnocchi Link header or uses the last resource ID as a marker.
+func (r ResourcePage) NextPageURL() (string, error) {
+	if r.history {
+		return gnocchi.NextPageURL(r.MarkerPageBase, 0)
+	}
+
+	s, err := ExtractResources(r)
+	if err != nil {
+		return "", err
+	}
+	return gnocchi.NextPageURL(r.MarkerPageBase, len(s))
 }
 
 // IsEmpty checks whether a ResourcePage struct is empty.
@@ -170,3 +251,81 @@ func ExtractResources(r pagination.Page) ([]Resource, error) {
 
 	return s, err
 }
+
+// AddMetricsResult represents the result of an add metrics operation.
+// Call its Extract method to interpret it as metrics of the Gnocchi resource.
+type AddMetricsResult struct {
+	gophercloud.Result
+}
+
+// Extract is a function that accepts a result and extracts all metrics of
+// the Gnocchi resource.
+func (r AddMetricsResult) Extract() ([]Metric, error) {
+	var s []Metric
+	err := r.ExtractInto(&s)
+	return s, err
+}
+
+// RemoveMetricResult represents the result of a remove metric operation.
+// Call its ExtractErr method to determine if the request succeeded or failed.
+type RemoveMetricResult struct {
+	gophercloud.ErrResult
+}
+
+// Metric represents a Gnocchi metric attached to a resource by its name.
+type Metric struct {
+	// ArchivePolicy is a Gnocchi archive policy that describes the aggregate
+	// storage policy of a metric.
+	ArchivePolicy archivepolicies.ArchivePolicy `json:"archive_policy"`
+
+	// ArchivePolicyName is a name of the Gnocchi archive policy that describes
+	// the aggregate storage policy of a metric.
+	ArchivePolicyName string `json:"archive_policy_name"`
+
+	// CreatedByProjectID contains the id of the Identity project that
+	// was used for a metric creation.
+	CreatedByProjectID string `json:"created_by_project_id"`
+
+	// CreatedByUserID contains the id of the Identity user
+	// that created the Gnocchi metric.
+	CreatedByUserID string `json:"created_by_user_id"`
+
+	// Creator shows who created the metric.
+	Creator string `json:"creator"`
+
+	// ID uniquely identifies the Gnocchi metric.
+	ID string `json:"id"`
+
+	// Name is a name of the metric inside the resource.
+	Name string `json:"name"`
+
+	// ResourceID identifies the Gnocchi resource of the metric.
+	ResourceID string `json:"resource_id"`
+
+	// Unit is a unit of measurement for measures of that Gnocchi metric.
+	Unit string `json:"unit"`
+}
+
+// MetricPage is the page returned by a pager when traversing over a collection
+// of metrics attached to a resource.
+type MetricPage struct {
+	pagination.SinglePageBase
+}
+
+// IsEmpty checks whether a MetricPage struct is empty.
+func (r MetricPage) IsEmpty() (bool, error) {
+	is, err := ExtractMetrics(r)
+	return len(is) == 0, err
+}
+
+// ExtractMetrics interprets the results of a single page from a ListMetrics() call,
+// producing a slice of Metric structs.
+func ExtractMetrics(r pagination.Page) ([]Metric, error) {
+	var s []Metric
+	err := (r.(MetricPage)).ExtractInto(&s)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, err
+}
@@ -0,0 +1,178 @@
+package resources
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gophercloud/utils/gnocchi"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/resourcetypes"
+)
+
+// uuidFormat matches UUIDs in all forms that Gnocchi accepts.
+var uuidFormat = regexp.MustCompile(`^(?:urn:uuid:)?\{?[0-9a-fA-F]{8}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{12}\}?$`)
+
+// datetimeFormats contains formats of timestamps that can be used as values
+// of datetime attributes.
+var datetimeFormats = []string{
+	time.RFC3339Nano,
+	gnocchi.RFC3339NanoTimezone,
+	gnocchi.RFC3339NanoNoTimezone,
+}
+
+// Validate checks CreateOpts extra attributes against the schema of the
+// provided resource type. All required attributes of the resource type
+// must be set.
+func (opts CreateOpts) Validate(resourceType resourcetypes.ResourceType) error {
+	return validateExtraAttributes(resourceType, opts.ExtraAttributes, true)
+}
+
+// Validate checks UpdateOpts extra attributes against the schema of the
+// provided resource type.
+func (opts UpdateOpts) Validate(resourceType resourcetypes.ResourceType) error {
+	return validateExtraAttributes(resourceType, opts.ExtraAttributes, false)
+}
+
+// validateExtraAttributes checks provided values against attributes of the
+// resource type in a sorted order of attribute names.
+func validateExtraAttributes(resourceType resourcetypes.ResourceType, extraAttributes map[string]interface{}, checkRequired bool) error {
+	names := make([]string, 0, len(extraAttributes))
+	for name := range extraAttributes {
+		if _, ok := resourceType.Attributes[name]; !ok {
+			return fmt.Errorf("attribute %q isn't defined in the %q resource type", name, resourceType.Name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if checkRequired {
+		required := make([]string, 0, len(resourceType.Attributes))
+		for name, attribute := range resourceType.Attributes {
+			if attribute.Required {
+				required = append(required, name)
+			}
+		}
+		sort.Strings(required)
+
+		for _, name := range required {
+			if _, ok := extraAttributes[name]; !ok {
+				return fmt.Errorf("missing input for the required %q attribute of the %q resource type", name, resourceType.Name)
+			}
+		}
+	}
+
+	for _, name := range names {
+		if err := validateAttribute(resourceType.Attributes[name], extraAttributes[name]); err != nil {
+			return fmt.Errorf("invalid value of the %q attribute: %s", name, err)
+		}
+	}
+
+	return nil
+}
+
+// validateAttribute checks a single value against the attribute schema.
+func validateAttribute(attribute resourcetypes.Attribute, value interface{}) error {
+	if value == nil {
+		if attribute.Required {
+			return fmt.Errorf("required attribute can't be null")
+		}
+		return nil
+	}
+
+	switch attribute.Type {
+	case resourcetypes.AttributeTypeString:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected a string, got %T", value)
+		}
+		length := utf8.RuneCountInString(s)
+		if attribute.MinLength != nil && length < *attribute.MinLength {
+			return fmt.Errorf("string is shorter than %d characters", *attribute.MinLength)
+		}
+		if attribute.MaxLength != nil && length > *attribute.MaxLength {
+			return fmt.Errorf("string is longer than %d characters", *attribute.MaxLength)
+		}
+	case resourcetypes.AttributeTypeUUID:
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected a UUID string, got %T", value)
+		}
+		if !uuidFormat.MatchString(s) {
+			return fmt.Errorf("%q isn't a valid UUID", s)
+		}
+	case resourcetypes.AttributeTypeNumber:
+		n, ok := numberValue(value)
+		if !ok {
+			return fmt.Errorf("expected a number, got %T", value)
+		}
+		if attribute.Min != nil && n < *attribute.Min {
+			return fmt.Errorf("%v is less than %v", n, *attribute.Min)
+		}
+		if attribute.Max != nil && n > *attribute.Max {
+			return fmt.Errorf("%v is greater than %v", n, *attribute.Max)
+		}
+	case resourcetypes.AttributeTypeBool:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("expected a bool, got %T", value)
+		}
+	case resourcetypes.AttributeTypeDatetime:
+		switch v := value.(type) {
+		case time.Time, *time.Time:
+		case string:
+			if !isDatetime(v) {
+				return fmt.Errorf("%q isn't a valid timestamp", v)
+			}
+		default:
+			return fmt.Errorf("expected a timestamp, got %T", value)
+		}
+	}
+
+	return nil
+}
+
+// numberValue converts all Go numeric types into float64.
+func numberValue(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// isDatetime checks that the string is a timestamp in one of the supported formats.
+func isDatetime(s string) bool {
+	for _, format := range datetimeFormats {
+		if _, err := time.Parse(format, s); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -3,6 +3,8 @@ package testing
 import (
 	"time"
 
+	"github.com/gophercloud/utils/gnocchi"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/archivepolicies"
 	"github.com/gophercloud/utils/gnocchi/metric/v1/resources"
 )
 
@@ -287,3 +289,250 @@ const ResourceUpdateCreateMetricsResponse = `
     "user_id": "bd5874d6-6662-4b24-a9f01c128871e4ac"
 }
 `
+
+// ResourceSearchRequest represents a raw search request.
+const ResourceSearchRequest = `
+{
+    "and": [
+        {
+            "eq": {
+                "project_id": "4154f08883334e0494c41155c33c0fc9"
+            }
+        },
+        {
+            "or": [
+                {
+                    "like": {
+                        "host": "compute%"
+                    }
+                },
+                {
+                    "not": {
+                        "in": {
+                            "type": [
+                                "compute_instance",
+                                "compute_instance_disk"
+                            ]
+                        }
+                    }
+                }
+            ]
+        },
+        {
+            "ge": {
+                "started_at": "2018-01-01T00:00:00"
+            }
+        }
+    ]
+}
+`
+
+// ResourceHistoryResult represents raw server response from a server to a history request.
+const ResourceHistoryResult = `[
+    {
+        "created_by_project_id": "3d40ca37723449118987b9f288f4ae84",
+        "created_by_user_id": "fdcfb420c09645e69e177a0bb1950884",
+        "creator": "fdcfb420c09645e69e177a0bb1950884:3d40ca37723449118987b9f288f4ae84",
+        "flavor_name": "2CPU4G",
+        "host": "compute010",
+        "ended_at": null,
+        "id": "1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc",
+        "metrics": {
+            "cpu.delta": "2df1515e-6325-4d49-af0d-1052f6462fe4"
+        },
+        "original_resource_id": "1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc",
+        "project_id": "4154f08883334e0494c41155c33c0fc9",
+        "revision_end": "2018-01-05T08:12:01.120233+00:00",
+        "revision_start": "2018-01-02T11:39:33.942419+00:00",
+        "started_at": "2018-01-02T11:39:33.942391+00:00",
+        "type": "compute_instance",
+        "user_id": "bd5874d666624b24a9f01c128871e4ac"
+    },
+    {
+        "created_by_project_id": "3d40ca37723449118987b9f288f4ae84",
+        "created_by_user_id": "fdcfb420c09645e69e177a0bb1950884",
+        "creator": "fdcfb420c09645e69e177a0bb1950884:3d40ca37723449118987b9f288f4ae84",
+        "flavor_name": "4CPU8G",
+        "host": "compute012",
+        "ended_at": null,
+        "id": "1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc",
+        "metrics": {
+            "cpu.delta": "2df1515e-6325-4d49-af0d-1052f6462fe4"
+        },
+        "original_resource_id": "1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc",
+        "project_id": "4154f08883334e0494c41155c33c0fc9",
+        "revision_end": null,
+        "revision_start": "2018-01-05T08:12:01.120233+00:00",
+        "started_at": "2018-01-02T11:39:33.942391+00:00",
+        "type": "compute_instance",
+        "user_id": "bd5874d666624b24a9f01c128871e4ac"
+    }
+]`
+
+// ResourceRevision1 is an expected representation of a first revision from the ResourceHistoryResult.
+var ResourceRevision1 = resources.Resource{
+	CreatedByProjectID: "3d40ca37723449118987b9f288f4ae84",
+	CreatedByUserID:    "fdcfb420c09645e69e177a0bb1950884",
+	Creator:            "fdcfb420c09645e69e177a0bb1950884:3d40ca37723449118987b9f288f4ae84",
+	ID:                 "1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc",
+	Metrics: map[string]string{
+		"cpu.delta": "2df1515e-6325-4d49-af0d-1052f6462fe4",
+	},
+	OriginalResourceID: "1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc",
+	ProjectID:          "4154f08883334e0494c41155c33c0fc9",
+	RevisionStart:      time.Date(2018, 1, 2, 11, 39, 33, 942419000, time.UTC),
+	RevisionEnd:        time.Date(2018, 1, 5, 8, 12, 1, 120233000, time.UTC),
+	StartedAt:          time.Date(2018, 1, 2, 11, 39, 33, 942391000, time.UTC),
+	EndedAt:            time.Time{},
+	Type:               "compute_instance",
+	UserID:             "bd5874d666624b24a9f01c128871e4ac",
+	ExtraAttributes: map[string]interface{}{
+		"flavor_name": "2CPU4G",
+		"host":        "compute010",
+	},
+}
+
+// ResourceRevision2 is an expected representation of a second revision from the ResourceHistoryResult.
+var ResourceRevision2 = resources.Resource{
+	CreatedByProjectID: "3d40ca37723449118987b9f288f4ae84",
+	CreatedByUserID:    "fdcfb420c09645e69e177a0bb1950884",
+	Creator:            "fdcfb420c09645e69e177a0bb1950884:3d40ca37723449118987b9f288f4ae84",
+	ID:                 "1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc",
+	Metrics: map[string]string{
+		"cpu.delta": "2df1515e-6325-4d49-af0d-1052f6462fe4",
+	},
+	OriginalResourceID: "1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc",
+	ProjectID:          "4154f08883334e0494c41155c33c0fc9",
+	RevisionStart:      time.Date(2018, 1, 5, 8, 12, 1, 120233000, time.UTC),
+	RevisionEnd:        time.Time{},
+	StartedAt:          time.Date(2018, 1, 2, 11, 39, 33, 942391000, time.UTC),
+	EndedAt:            time.Time{},
+	Type:               "compute_instance",
+	UserID:             "bd5874d666624b24a9f01c128871e4ac",
+	ExtraAttributes: map[string]interface{}{
+		"flavor_name": "4CPU8G",
+		"host":        "compute012",
+	},
+}
+
+// ResourceBatchDeleteRequest represents a raw batch delete request.
+const ResourceBatchDeleteRequest = `
+{
+    "and": [
+        {
+            "ne": {
+                "ended_at": null
+            }
+        },
+        {
+            "lt": {
+                "ended_at": "2018-01-01T00:00:00"
+            }
+        }
+    ]
+}
+`
+
+// ResourceBatchDeleteResult represents raw server response from a server to a batch delete request.
+const ResourceBatchDeleteResult = `
+{
+    "deleted": 12
+}
+`
+
+// ResourceMetricsListResult represents raw server response from a server to a list metrics request.
+const ResourceMetricsListResult = `[
+    {
+        "archive_policy": {
+            "aggregation_methods": [
+                "mean"
+            ],
+            "back_window": 0,
+            "definition": [
+                {
+                    "granularity": "0:05:00",
+                    "points": 8640,
+                    "timespan": "30 days, 0:00:00"
+                }
+            ],
+            "name": "medium"
+        },
+        "created_by_project_id": "3d40ca37723449118987b9f288f4ae84",
+        "created_by_user_id": "fdcfb420c09645e69e177a0bb1950884",
+        "creator": "fdcfb420c09645e69e177a0bb1950884:3d40ca37723449118987b9f288f4ae84",
+        "id": "2df1515e-6325-4d49-af0d-1052f6462fe4",
+        "name": "cpu_util",
+        "resource_id": "1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc",
+        "unit": "ns"
+    }
+]`
+
+// ResourceMetric1 is an expected representation of a first metric from the ResourceMetricsListResult.
+var ResourceMetric1 = resources.Metric{
+	ArchivePolicy: archivepolicies.ArchivePolicy{
+		AggregationMethods: []string{
+			"mean",
+		},
+		BackWindow: 0,
+		Definition: []archivepolicies.ArchivePolicyDefinition{
+			{
+				Granularity: gnocchi.Duration(5 * time.Minute),
+				Points:      8640,
+				TimeSpan:    gnocchi.Duration(30 * 24 * time.Hour),
+			},
+		},
+		Name: "medium",
+	},
+	CreatedByProjectID: "3d40ca37723449118987b9f288f4ae84",
+	CreatedByUserID:    "fdcfb420c09645e69e177a0bb1950884",
+	Creator:            "fdcfb420c09645e69e177a0bb1950884:3d40ca37723449118987b9f288f4ae84",
+	ID:                 "2df1515e-6325-4d49-af0d-1052f6462fe4",
+	Name:               "cpu_util",
+	ResourceID:         "1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc",
+	Unit:               "ns",
+}
+
+// ResourceAddMetricsRequest represents a raw add metrics request.
+const ResourceAddMetricsRequest = `
+{
+    "cpu_util": {
+        "archive_policy_name": "medium",
+        "unit": "ns"
+    }
+}
+`
+
+// ResourceTypeComputeInstanceSchema represents a raw resource type that is
+// used to validate extra attributes of resources.
+const ResourceTypeComputeInstanceSchema = `
+{
+    "attributes": {
+        "display_name": {
+            "max_length": 16,
+            "min_length": 1,
+            "required": true,
+            "type": "string"
+        },
+        "image_ref": {
+            "required": false,
+            "type": "uuid"
+        },
+        "launched_at": {
+            "required": false,
+            "type": "datetime"
+        },
+        "vcpus": {
+            "max": 64,
+            "min": 1,
+            "required": false,
+            "type": "number"
+        },
+        "deleted": {
+            "required": false,
+            "type": "bool"
+        }
+    },
+    "name": "compute_instance",
+    "state": "active"
+}
+`
@@ -51,6 +51,50 @@ func TestList(t *testing.T) {
 	}
 }
 
+func TestListWithLinkHeader(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	requests := 0
+	th.Mux.HandleFunc("/v1/resource/generic", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		requests++
+
+		w.Header().Add("Content-Type", "application/json")
+
+		r.ParseForm()
+		marker := r.Form.Get("marker")
+		switch marker {
+		case "":
+			th.TestFormValues(t, r, map[string]string{
+				"limit": "2",
+			})
+			w.Header().Add("Link", fmt.Sprintf(`<%s/v1/resource/generic?limit=2&marker=789a7f65-977d-40f4-beed-f717100125f5&sort=revision_start:asc>; rel="next"`, th.Server.URL))
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, ResourceListResult)
+		case "789a7f65-977d-40f4-beed-f717100125f5":
+			th.TestFormValues(t, r, map[string]string{
+				"limit":  "2",
+				"marker": "789a7f65-977d-40f4-beed-f717100125f5",
+				"sort":   "revision_start:asc",
+			})
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `[]`)
+		default:
+			t.Fatalf("Unexpected marker: [%s]", marker)
+		}
+	})
+
+	allPages, err := resources.List(fake.ServiceClient(), resources.ListOpts{Limit: 2}, "generic").AllPages()
+	th.AssertNoErr(t, err)
+
+	actual, err := resources.ExtractResources(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []resources.Resource{Resource1, Resource2}, actual)
+	th.CheckEquals(t, 2, requests)
+}
+
 func TestGet(t *testing.T) {
 	th.SetupHTTP()
 	defer th.TeardownHTTP()
@@ -89,6 +133,12 @@ func TestGet(t *testing.T) {
 	th.AssertDeepEquals(t, s.ExtraAttributes, map[string]interface{}{
 		"iface_name": "eth0",
 	})
+
+	var attributes struct {
+		IfaceName string `json:"iface_name"`
+	}
+	th.AssertNoErr(t, s.ExtractExtraAttributesInto(&attributes))
+	th.AssertEquals(t, attributes.IfaceName, "eth0")
 }
 
 func TestCreateWithoutMetrics(t *testing.T) {
@@ -336,3 +386,305 @@ func TestDelete(t *testing.T) {
 	res := resources.Delete(fake.ServiceClient(), "generic", "23d5d3f7-9dfa-4f73-b72b-8b0b0063ec55")
 	th.AssertNoErr(t, res.Err)
 }
+
+func TestSearch(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/search/resource/generic", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestJSONRequest(t, r, ResourceSearchRequest)
+		th.TestFormValues(t, r, map[string]string{
+			"details": "true",
+			"limit":   "2",
+			"sort":    "started_at:desc",
+		})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprintf(w, ResourceListResult)
+	})
+
+	opts := resources.SearchOpts{
+		Query: resources.And(
+			resources.Eq("project_id", "4154f08883334e0494c41155c33c0fc9"),
+			resources.Or(
+				resources.Like("host", "compute%"),
+				resources.Not(resources.In("type", "compute_instance", "compute_instance_disk")),
+			),
+			resources.Ge("started_at", "2018-01-01T00:00:00"),
+		),
+		Details: true,
+		Limit:   2,
+		Sort:    []string{"started_at:desc"},
+	}
+	actual, err := resources.Search(fake.ServiceClient(), "generic", opts).Extract()
+	th.AssertNoErr(t, err)
+
+	expected := []resources.Resource{
+		Resource1,
+		Resource2,
+	}
+	th.CheckDeepEquals(t, expected, actual)
+}
+
+func TestEachSearchPage(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	requests := 0
+	th.Mux.HandleFunc("/v1/search/resource/generic", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestJSONRequest(t, r, `{"eq": {"project_id": "4154f08883334e0494c41155c33c0fc9"}}`)
+		requests++
+
+		w.Header().Add("Content-Type", "application/json")
+		switch r.URL.Query().Get("marker") {
+		case "":
+			th.TestFormValues(t, r, map[string]string{
+				"limit": "2",
+			})
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, ResourceListResult)
+		case Resource2.ID:
+			th.TestFormValues(t, r, map[string]string{
+				"limit":  "2",
+				"marker": Resource2.ID,
+			})
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `[]`)
+		default:
+			t.Errorf("Unexpected marker %q", r.URL.Query().Get("marker"))
+		}
+	})
+
+	opts := resources.SearchOpts{
+		Query: resources.Eq("project_id", "4154f08883334e0494c41155c33c0fc9"),
+		Limit: 2,
+	}
+	var actual []resources.Resource
+	err := resources.EachSearchPage(fake.ServiceClient(), "generic", opts, func(page []resources.Resource) (bool, error) {
+		actual = append(actual, page...)
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+
+	expected := []resources.Resource{
+		Resource1,
+		Resource2,
+	}
+	th.CheckDeepEquals(t, expected, actual)
+	th.CheckEquals(t, 2, requests)
+}
+
+func TestEachSearchPageLinkHeader(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	requests := 0
+	th.Mux.HandleFunc("/v1/search/resource/generic", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestJSONRequest(t, r, `{}`)
+		requests++
+
+		w.Header().Add("Content-Type", "application/json")
+		switch r.URL.Query().Get("marker") {
+		case "":
+			// Gnocchi reports the next page if the server maximum limit
+			// is reached.
+			w.Header().Add("Link", `<http://localhost/v1/search/resource/generic?limit=2&marker=`+Resource2.ID+`>; rel="next"`)
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, ResourceListResult)
+		case Resource2.ID:
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, ResourceListResult)
+		default:
+			t.Errorf("Unexpected marker %q", r.URL.Query().Get("marker"))
+		}
+	})
+
+	var actual []resources.Resource
+	err := resources.EachSearchPage(fake.ServiceClient(), "generic", resources.SearchOpts{}, func(page []resources.Resource) (bool, error) {
+		actual = append(actual, page...)
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, 4, len(actual))
+	th.CheckEquals(t, 2, requests)
+
+	// Stop after the first page.
+	requests = 0
+	err = resources.EachSearchPage(fake.ServiceClient(), "generic", resources.SearchOpts{}, func(page []resources.Resource) (bool, error) {
+		return false, nil
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, 1, requests)
+}
+
+func TestSearchFilter(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/search/resource/generic", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestJSONRequest(t, r, `{}`)
+		th.TestFormValues(t, r, map[string]string{
+			"filter": "host like 'compute%'",
+		})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprintf(w, ResourceListResult)
+	})
+
+	opts := resources.SearchOpts{
+		Filter: "host like 'compute%'",
+	}
+	actual, err := resources.Search(fake.ServiceClient(), "generic", opts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, 2, len(actual))
+}
+
+func TestHistory(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/resource/compute_instance/1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc/history", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{
+			"details": "true",
+			"sort":    "revision_start:asc",
+		})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprintf(w, ResourceHistoryResult)
+	})
+
+	opts := resources.HistoryOpts{
+		Details: true,
+		Sort:    []string{"revision_start:asc"},
+	}
+	count := 0
+	err := resources.History(fake.ServiceClient(), "compute_instance", "1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc", opts).EachPage(func(page pagination.Page) (bool, error) {
+		count++
+		actual, err := resources.ExtractResources(page)
+		th.AssertNoErr(t, err)
+
+		expected := []resources.Resource{
+			ResourceRevision1,
+			ResourceRevision2,
+		}
+		th.CheckDeepEquals(t, expected, actual)
+
+		return true, nil
+	})
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, 1, count)
+}
+
+func TestBatchDelete(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/resource/compute_instance", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestJSONRequest(t, r, ResourceBatchDeleteRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprintf(w, ResourceBatchDeleteResult)
+	})
+
+	query := resources.And(
+		resources.Ne("ended_at", nil),
+		resources.Lt("ended_at", "2018-01-01T00:00:00"),
+	)
+	deleted, err := resources.BatchDelete(fake.ServiceClient(), "compute_instance", query).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 12, deleted)
+}
+
+func TestBatchDeleteEmptyQuery(t *testing.T) {
+	res := resources.BatchDelete(fake.ServiceClient(), "compute_instance", resources.Query{})
+	if res.Err == nil {
+		t.Fatalf("Expected an error for an empty BatchDelete query")
+	}
+}
+
+func TestListMetrics(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/resource/compute_instance/1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc/metric", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprintf(w, ResourceMetricsListResult)
+	})
+
+	allPages, err := resources.ListMetrics(fake.ServiceClient(), "compute_instance", "1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc").AllPages()
+	th.AssertNoErr(t, err)
+
+	actual, err := resources.ExtractMetrics(allPages)
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []resources.Metric{ResourceMetric1}, actual)
+}
+
+func TestAddMetrics(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/resource/compute_instance/1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc/metric", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestHeader(t, r, "Content-Type", "application/json")
+		th.TestJSONRequest(t, r, ResourceAddMetricsRequest)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprintf(w, ResourceMetricsListResult)
+	})
+
+	opts := resources.AddMetricsOpts{
+		Metrics: map[string]interface{}{
+			"cpu_util": map[string]string{
+				"archive_policy_name": "medium",
+				"unit":                "ns",
+			},
+		},
+	}
+	actual, err := resources.AddMetrics(fake.ServiceClient(), "compute_instance", "1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc", opts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, []resources.Metric{ResourceMetric1}, actual)
+}
+
+func TestRemoveMetric(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/resource/compute_instance/1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc/metric/cpu_util", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "DELETE")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	res := resources.RemoveMetric(fake.ServiceClient(), "compute_instance", "1f3a0724-1807-4bd1-81f9-ee18c8ff6ccc", "cpu_util")
+	th.AssertNoErr(t, res.Err)
+}
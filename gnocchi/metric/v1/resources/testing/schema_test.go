@@ -0,0 +1,101 @@
+package testing
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/resources"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/resourcetypes"
+)
+
+func TestCreateOptsValidate(t *testing.T) {
+	var resourceType resourcetypes.ResourceType
+	th.AssertNoErr(t, json.Unmarshal([]byte(ResourceTypeComputeInstanceSchema), &resourceType))
+
+	launchedAt := time.Date(2018, 1, 1, 11, 44, 31, 0, time.UTC)
+	createOpts := resources.CreateOpts{
+		ID: "23d5d3f7-9dfa-4f73-b72b-8b0b0063ec55",
+		ExtraAttributes: map[string]interface{}{
+			"display_name": "test-instance",
+			"image_ref":    "4d8f5b8b-e4f6-4d18-8d5f-8e4b0e4c3a5b",
+			"launched_at":  launchedAt,
+			"vcpus":        4,
+			"deleted":      false,
+		},
+	}
+	th.AssertNoErr(t, createOpts.Validate(resourceType))
+
+	createOpts.ExtraAttributes["launched_at"] = "2018-01-01T11:44:31.742011+00:00"
+	createOpts.ExtraAttributes["image_ref"] = nil
+	th.AssertNoErr(t, createOpts.Validate(resourceType))
+
+	invalid := map[string]map[string]interface{}{
+		"missing required attribute": {
+			"vcpus": 4,
+		},
+		"unknown attribute": {
+			"display_name": "test-instance",
+			"flavor":       "m1.small",
+		},
+		"null required attribute": {
+			"display_name": nil,
+		},
+		"too short string": {
+			"display_name": "",
+		},
+		"too long string": {
+			"display_name": "a-very-long-instance-name",
+		},
+		"invalid string": {
+			"display_name": 42,
+		},
+		"invalid uuid": {
+			"display_name": "test-instance",
+			"image_ref":    "cirros",
+		},
+		"invalid datetime": {
+			"display_name": "test-instance",
+			"launched_at":  "yesterday",
+		},
+		"too small number": {
+			"display_name": "test-instance",
+			"vcpus":        0,
+		},
+		"too large number": {
+			"display_name": "test-instance",
+			"vcpus":        128.0,
+		},
+		"invalid bool": {
+			"display_name": "test-instance",
+			"deleted":      "false",
+		},
+	}
+
+	for name, extraAttributes := range invalid {
+		createOpts := resources.CreateOpts{
+			ExtraAttributes: extraAttributes,
+		}
+		if err := createOpts.Validate(resourceType); err == nil {
+			t.Errorf("Expected an error for the %s case", name)
+		}
+	}
+}
+
+func TestUpdateOptsValidate(t *testing.T) {
+	var resourceType resourcetypes.ResourceType
+	th.AssertNoErr(t, json.Unmarshal([]byte(ResourceTypeComputeInstanceSchema), &resourceType))
+
+	updateOpts := resources.UpdateOpts{
+		ExtraAttributes: map[string]interface{}{
+			"vcpus": 8,
+		},
+	}
+	th.AssertNoErr(t, updateOpts.Validate(resourceType))
+
+	updateOpts.ExtraAttributes["vcpus"] = 65
+	if err := updateOpts.Validate(resourceType); err == nil {
+		t.Fatal("Expected an error for a too large number")
+	}
+}
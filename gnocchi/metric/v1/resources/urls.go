@@ -2,7 +2,10 @@ package resources
 
 import "github.com/gophercloud/gophercloud"
 
-const resourcePath = "resource"
+const (
+	resourcePath = "resource"
+	searchPath   = "search/resource"
+)
 
 func rootURL(c *gophercloud.ServiceClient, resourceType string) string {
 	return c.ServiceURL(resourcePath, resourceType)
@@ -20,6 +23,10 @@ func getURL(c *gophercloud.ServiceClient, resourceType, resourceID string) strin
 	return resourceURL(c, resourceType, resourceID)
 }
 
+func historyURL(c *gophercloud.ServiceClient, resourceType, resourceID string) string {
+	return c.ServiceURL(resourcePath, resourceType, resourceID, "history")
+}
+
 func createURL(c *gophercloud.ServiceClient, resourceType string) string {
 	return rootURL(c, resourceType)
 }
@@ -31,3 +38,27 @@ func updateURL(c *gophercloud.ServiceClient, resourceType, resourceID string) st
 func deleteURL(c *gophercloud.ServiceClient, resourceType, resourceID string) string {
 	return resourceURL(c, resourceType, resourceID)
 }
+
+func searchURL(c *gophercloud.ServiceClient, resourceType string) string {
+	return c.ServiceURL(searchPath, resourceType)
+}
+
+func batchDeleteURL(c *gophercloud.ServiceClient, resourceType string) string {
+	return rootURL(c, resourceType)
+}
+
+func resourceMetricsURL(c *gophercloud.ServiceClient, resourceType, resourceID string) string {
+	return c.ServiceURL(resourcePath, resourceType, resourceID, "metric")
+}
+
+func listMetricsURL(c *gophercloud.ServiceClient, resourceType, resourceID string) string {
+	return resourceMetricsURL(c, resourceType, resourceID)
+}
+
+func addMetricsURL(c *gophercloud.ServiceClient, resourceType, resourceID string) string {
+	return resourceMetricsURL(c, resourceType, resourceID)
+}
+
+func removeMetricURL(c *gophercloud.ServiceClient, resourceType, resourceID, metricName string) string {
+	return c.ServiceURL(resourcePath, resourceType, resourceID, "metric", metricName)
+}
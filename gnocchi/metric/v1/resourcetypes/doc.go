@@ -50,23 +50,45 @@ Example of Creating a resource type
     panic(err)
   }
 
-Example of Updating a resource type
+Example of Creating a resource type with typed attributes
 
-  enabledAttributeOptions := resourcetypes.AttributeOpts{
-    Details: map[string]interface{}{
-      "required": true,
-      "options": map[string]interface{}{
-        "fill": true,
-      },
-    },
-    Type: "bool",
+  minNameLength := 1
+  displayNameAttributeOptions, err := resourcetypes.StringAttribute(true, &minNameLength, nil)
+  if err != nil {
+    panic(err)
   }
-  parendIDAttributeOptions := resourcetypes.AttributeOpts{
-    Details: map[string]interface{}{
-      "required": false,
+
+  maxVCPUs := 64.0
+  vcpusAttributeOptions, err := resourcetypes.NumberAttribute(false, nil, &maxVCPUs)
+  if err != nil {
+    panic(err)
+  }
+
+  resourceTypeOpts := resourcetypes.CreateOpts{
+    Name: "compute_instance",
+    Attributes: map[string]resourcetypes.AttributeOpts{
+      "display_name": displayNameAttributeOptions,
+      "image_ref":    resourcetypes.UUIDAttribute(false),
+      "vcpus":        vcpusAttributeOptions,
+      "deleted":      resourcetypes.BoolAttribute(false),
+      "launched_at":  resourcetypes.DatetimeAttribute(false),
     },
-    Type: "uuid",
   }
+  resourceType, err := resourcetypes.Create(gnocchiClient, resourceTypeOpts).Extract()
+  if err != nil {
+    panic(err)
+  }
+
+  for name, attribute := range resourceType.Attributes {
+    if attribute.Type == resourcetypes.AttributeTypeString && attribute.MaxLength != nil {
+      fmt.Printf("%s: up to %d characters\n", name, *attribute.MaxLength)
+    }
+  }
+
+Example of Updating a resource type
+
+  enabledAttributeOptions := resourcetypes.BoolAttribute(true).WithFill(true)
+  parendIDAttributeOptions := resourcetypes.UUIDAttribute(false)
   resourceTypeOpts := resourcetypes.UpdateOpts{
     Attributes: []resourcetypes.AttributeUpdateOpts{
       {
@@ -90,6 +112,51 @@ Example of Updating a resource type
     panic(err)
   }
 
+Example of Planning changes of a resource type in the dry-run mode
+
+  desiredOpts := resourcetypes.CreateOpts{
+    Name: "compute_instance_network",
+    Attributes: map[string]resourcetypes.AttributeOpts{
+      "port_name": resourcetypes.AttributeOpts{
+        Type: "string",
+        Details: map[string]interface{}{
+          "max_length": 128,
+          "required":   false,
+        },
+      },
+      "enabled": resourcetypes.AttributeOpts{
+        Type: "bool",
+        Details: map[string]interface{}{
+          "required": true,
+        },
+      },
+    },
+  }
+  applyOpts := resourcetypes.ApplyOpts{
+    DiffOpts: resourcetypes.DiffOpts{
+      Fill: map[string]interface{}{
+        "enabled": true,
+      },
+    },
+    DryRun: true,
+  }
+  plan, err := resourcetypes.Apply(gnocchiClient, desiredOpts, applyOpts)
+  if err != nil {
+    panic(err)
+  }
+
+  for _, operation := range plan.Operations {
+    fmt.Printf("%s %s\n", operation.Operation, operation.Name)
+  }
+
+Example of Applying changes of a resource type
+
+  applyOpts.DryRun = false
+  result, err := resourcetypes.Apply(gnocchiClient, desiredOpts, applyOpts)
+  if err != nil {
+    panic(err)
+  }
+
 Example of Deleting a resource type
 
   err := resourcetypes.Delete(gnocchiClient, resourceType).ExtractErr()
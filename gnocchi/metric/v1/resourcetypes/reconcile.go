@@ -0,0 +1,211 @@
+package resourcetypes
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+// DiffOpts specifies how the desired resource type is compared with the
+// existing one.
+type DiffOpts struct {
+	// Fill contains values for new required attributes that will be set in
+	// already existing resources. Gnocchi requires a fill value for every new
+	// required attribute, it can also be set as the "options" detail of the
+	// attribute itself.
+	Fill map[string]interface{}
+
+	// Replace allows to remove and add again attributes with changed definitions.
+	// Gnocchi can't modify attributes, so values of such attributes in existing
+	// resources are lost.
+	Replace bool
+
+	// Prune allows to remove attributes that are missing in the desired
+	// resource type. Values of such attributes in existing resources are lost.
+	// Missing attributes are kept if it isn't set.
+	Prune bool
+}
+
+// Diff compares the desired resource type with the existing one and returns
+// operations that need to be performed by the Update request.
+//
+// Attributes that are missing in the desired resource type are only removed
+// if the Prune option is set, otherwise they are kept.
+// Details that aren't set in the desired attribute are ignored, so server
+// defaults like the "max_length" of strings don't produce changes.
+func Diff(current ResourceType, desired CreateOpts, opts DiffOpts) ([]AttributeUpdateOpts, error) {
+	if desired.Name != current.Name {
+		return nil, fmt.Errorf("can't compare the %q resource type with the %q one", desired.Name, current.Name)
+	}
+
+	var removed, added []string
+	if opts.Prune {
+		for name := range current.Attributes {
+			if _, ok := desired.Attributes[name]; !ok {
+				removed = append(removed, name)
+			}
+		}
+	}
+	for name, desiredAttribute := range desired.Attributes {
+		currentAttribute, ok := current.Attributes[name]
+		if !ok {
+			added = append(added, name)
+			continue
+		}
+
+		equal, err := attributeEquals(currentAttribute, desiredAttribute)
+		if err != nil {
+			return nil, err
+		}
+		if equal {
+			continue
+		}
+		if !opts.Replace {
+			return nil, fmt.Errorf("definition of the %q attribute has changed, but Gnocchi can't modify attributes", name)
+		}
+		removed = append(removed, name)
+		added = append(added, name)
+	}
+	sort.Strings(removed)
+	sort.Strings(added)
+
+	operations := make([]AttributeUpdateOpts, 0, len(removed)+len(added))
+	for _, name := range removed {
+		operations = append(operations, AttributeUpdateOpts{
+			Name:      name,
+			Operation: AttributeRemove,
+		})
+	}
+	for _, name := range added {
+		value, err := attributeWithFill(name, desired.Attributes[name], opts.Fill)
+		if err != nil {
+			return nil, err
+		}
+		operations = append(operations, AttributeUpdateOpts{
+			Name:      name,
+			Operation: AttributeAdd,
+			Value:     value,
+		})
+	}
+
+	return operations, nil
+}
+
+// ApplyOpts specifies how the desired resource type is applied.
+type ApplyOpts struct {
+	DiffOpts
+
+	// DryRun allows to only plan operations without changing the resource type.
+	DryRun bool
+}
+
+// ApplyResult represents the result of an Apply call.
+type ApplyResult struct {
+	// Created is set if the resource type doesn't exist and needs to be created.
+	Created bool
+
+	// Operations is a list of planned or performed attribute operations.
+	Operations []AttributeUpdateOpts
+
+	// ResourceType is the resource type after changes. It isn't set in the
+	// dry-run mode if the resource type doesn't exist.
+	ResourceType *ResourceType
+}
+
+// Apply retrieves the existing resource type and reconciles it with the desired one.
+// The resource type is created if it doesn't exist yet.
+func Apply(client *gophercloud.ServiceClient, desired CreateOpts, opts ApplyOpts) (*ApplyResult, error) {
+	current, err := Get(client, desired.Name).Extract()
+	if err != nil {
+		if _, ok := err.(gophercloud.ErrDefault404); !ok {
+			return nil, err
+		}
+
+		result := &ApplyResult{Created: true}
+		if opts.DryRun {
+			return result, nil
+		}
+		result.ResourceType, err = Create(client, desired).Extract()
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	operations, err := Diff(*current, desired, opts.DiffOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ApplyResult{
+		Operations:   operations,
+		ResourceType: current,
+	}
+	if opts.DryRun || len(operations) == 0 {
+		return result, nil
+	}
+
+	result.ResourceType, err = Update(client, desired.Name, UpdateOpts{Attributes: operations}).Extract()
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// attributeEquals checks that all set details of the desired attribute match
+// the existing attribute.
+func attributeEquals(current Attribute, desired AttributeOpts) (bool, error) {
+	if current.Type != desired.Type {
+		return false, nil
+	}
+
+	// Convert desired details into JSON types to compare them with the
+	// server response.
+	b, err := json.Marshal(desired.Details)
+	if err != nil {
+		return false, err
+	}
+	var details map[string]interface{}
+	if err := json.Unmarshal(b, &details); err != nil {
+		return false, err
+	}
+
+	for k, v := range details {
+		// Options are only used during the attribute creation.
+		if k == "options" {
+			continue
+		}
+		if !reflect.DeepEqual(current.Details[k], v) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// attributeWithFill returns options of a new attribute with the fill value
+// for required attributes.
+func attributeWithFill(name string, attribute AttributeOpts, fill map[string]interface{}) (*AttributeOpts, error) {
+	if required, _ := attribute.Details["required"].(bool); !required {
+		return &attribute, nil
+	}
+
+	if options, ok := attribute.Details["options"].(map[string]interface{}); ok {
+		if _, ok := options["fill"]; ok {
+			return &attribute, nil
+		}
+	}
+
+	value, ok := fill[name]
+	if !ok {
+		return nil, fmt.Errorf("missing input for the fill value of the new required %q attribute", name)
+	}
+
+	attribute = attribute.WithFill(value)
+
+	return &attribute, nil
+}
@@ -50,6 +50,131 @@ func (opts AttributeOpts) ToMap() (map[string]interface{}, error) {
 	return b, nil
 }
 
+// Types of Gnocchi resource type attributes.
+const (
+	// AttributeTypeString represents a string attribute with length limits.
+	AttributeTypeString = "string"
+
+	// AttributeTypeUUID represents a UUID attribute.
+	AttributeTypeUUID = "uuid"
+
+	// AttributeTypeNumber represents a number attribute with value limits.
+	AttributeTypeNumber = "number"
+
+	// AttributeTypeBool represents a boolean attribute.
+	AttributeTypeBool = "bool"
+
+	// AttributeTypeDatetime represents a timestamp attribute.
+	AttributeTypeDatetime = "datetime"
+)
+
+// MaxStringLength is the maximum length of a string attribute allowed by
+// Gnocchi.
+const MaxStringLength = 255
+
+// StringAttribute returns options of a string attribute with the provided
+// length limits. Nil limits are omitted, Gnocchi allows strings up to
+// MaxStringLength characters by default. An error is returned if a limit is
+// negative, the maximum length exceeds MaxStringLength or the minimum length
+// exceeds the maximum length.
+func StringAttribute(required bool, minLength, maxLength *int) (AttributeOpts, error) {
+	details := map[string]interface{}{
+		"required": required,
+	}
+	if minLength != nil {
+		if *minLength < 0 {
+			return AttributeOpts{}, fmt.Errorf("min_length must not be negative, got %d", *minLength)
+		}
+		details["min_length"] = *minLength
+	}
+	if maxLength != nil {
+		if *maxLength < 0 {
+			return AttributeOpts{}, fmt.Errorf("max_length must not be negative, got %d", *maxLength)
+		}
+		if *maxLength > MaxStringLength {
+			return AttributeOpts{}, fmt.Errorf("max_length must not exceed %d, got %d", MaxStringLength, *maxLength)
+		}
+		details["max_length"] = *maxLength
+	}
+	if minLength != nil && maxLength != nil && *minLength > *maxLength {
+		return AttributeOpts{}, fmt.Errorf("min_length %d must not exceed max_length %d", *minLength, *maxLength)
+	}
+
+	return AttributeOpts{
+		Type:    AttributeTypeString,
+		Details: details,
+	}, nil
+}
+
+// UUIDAttribute returns options of a UUID attribute.
+func UUIDAttribute(required bool) AttributeOpts {
+	return AttributeOpts{
+		Type: AttributeTypeUUID,
+		Details: map[string]interface{}{
+			"required": required,
+		},
+	}
+}
+
+// NumberAttribute returns options of a number attribute with the provided
+// value limits. Nil limits make the attribute unbounded. An error is returned
+// if the minimum exceeds the maximum.
+func NumberAttribute(required bool, min, max *float64) (AttributeOpts, error) {
+	details := map[string]interface{}{
+		"required": required,
+	}
+	if min != nil {
+		details["min"] = *min
+	}
+	if max != nil {
+		details["max"] = *max
+	}
+	if min != nil && max != nil && *min > *max {
+		return AttributeOpts{}, fmt.Errorf("min %v must not exceed max %v", *min, *max)
+	}
+
+	return AttributeOpts{
+		Type:    AttributeTypeNumber,
+		Details: details,
+	}, nil
+}
+
+// BoolAttribute returns options of a boolean attribute.
+func BoolAttribute(required bool) AttributeOpts {
+	return AttributeOpts{
+		Type: AttributeTypeBool,
+		Details: map[string]interface{}{
+			"required": required,
+		},
+	}
+}
+
+// DatetimeAttribute returns options of a timestamp attribute.
+func DatetimeAttribute(required bool) AttributeOpts {
+	return AttributeOpts{
+		Type: AttributeTypeDatetime,
+		Details: map[string]interface{}{
+			"required": required,
+		},
+	}
+}
+
+// WithFill returns a copy of the attribute options with the value that is
+// used to fill the attribute in existing resources. Gnocchi requires it to
+// add a new required attribute to the existing resource type.
+func (opts AttributeOpts) WithFill(value interface{}) AttributeOpts {
+	details := make(map[string]interface{}, len(opts.Details)+1)
+	for k, v := range opts.Details {
+		details[k] = v
+	}
+	details["options"] = map[string]interface{}{
+		"fill": value,
+	}
+	opts.Details = details
+
+	return opts
+}
+
 // CreateOpts specifies parameters of a new Gnocchi resource type.
 type CreateOpts struct {
 	// Attributes is a collection of keys and values of different resource types.
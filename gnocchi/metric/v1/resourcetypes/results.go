@@ -61,6 +61,21 @@ type Attribute struct {
 
 	// Details represents different attribute fields.
 	Details map[string]interface{}
+
+	// Required shows if the attribute must be set in every resource.
+	Required bool
+
+	// MinLength is a minimal length of a string attribute.
+	MinLength *int
+
+	// MaxLength is a maximal length of a string attribute.
+	MaxLength *int
+
+	// Min is a minimal value of a number attribute.
+	Min *float64
+
+	// Max is a maximal value of a number attribute.
+	Max *float64
 }
 
 // UnmarshalJSON helps to unmarshal ResourceType fields into needed values.
@@ -102,6 +117,7 @@ func (r *ResourceType) UnmarshalJSON(b []byte) error {
 				attribute.Details[k] = v
 			}
 		}
+		attribute.populateTypedDetails()
 		attributes[attributeName] = *attribute
 	}
 
@@ -110,6 +126,26 @@ func (r *ResourceType) UnmarshalJSON(b []byte) error {
 	return err
 }
 
+// populateTypedDetails fills typed attribute fields from its details.
+func (r *Attribute) populateTypedDetails() {
+	r.Required, _ = r.Details["required"].(bool)
+
+	if v, ok := r.Details["min_length"].(float64); ok {
+		minLength := int(v)
+		r.MinLength = &minLength
+	}
+	if v, ok := r.Details["max_length"].(float64); ok {
+		maxLength := int(v)
+		r.MaxLength = &maxLength
+	}
+	if v, ok := r.Details["min"].(float64); ok {
+		r.Min = &v
+	}
+	if v, ok := r.Details["max"].(float64); ok {
+		r.Max = &v
+	}
+}
+
 // ResourceTypePage abstracts the raw results of making a List() request against
 // the Gnocchi API.
 //
@@ -0,0 +1,114 @@
+package testing
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/resourcetypes"
+)
+
+func TestTypedAttributes(t *testing.T) {
+	displayName, err := resourcetypes.StringAttribute(true, gophercloud.IntToPointer(1), gophercloud.IntToPointer(64))
+	th.AssertNoErr(t, err)
+
+	minVCPUs := 1.0
+	vcpus, err := resourcetypes.NumberAttribute(false, &minVCPUs, nil)
+	th.AssertNoErr(t, err)
+
+	opts := resourcetypes.CreateOpts{
+		Name: "compute_instance",
+		Attributes: map[string]resourcetypes.AttributeOpts{
+			"display_name": displayName.WithFill("unknown"),
+			"image_ref":    resourcetypes.UUIDAttribute(false),
+			"vcpus":        vcpus,
+			"deleted":      resourcetypes.BoolAttribute(false),
+			"launched_at":  resourcetypes.DatetimeAttribute(false),
+		},
+	}
+
+	actual, err := opts.ToResourceTypeCreateMap()
+	th.AssertNoErr(t, err)
+	th.CheckJSONEquals(t, ResourceTypeCreateWithTypedAttributesRequest, actual)
+}
+
+func TestStringAttributeWithoutLimits(t *testing.T) {
+	actual, err := resourcetypes.StringAttribute(false, nil, nil)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, resourcetypes.AttributeTypeString, actual.Type)
+	th.CheckDeepEquals(t, map[string]interface{}{"required": false}, actual.Details)
+
+	actual, err = resourcetypes.StringAttribute(false, nil, gophercloud.IntToPointer(128))
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, map[string]interface{}{"required": false, "max_length": 128}, actual.Details)
+}
+
+func TestStringAttributeInvalidLimits(t *testing.T) {
+	testCases := []struct {
+		minLength *int
+		maxLength *int
+	}{
+		{gophercloud.IntToPointer(-1), nil},
+		{nil, gophercloud.IntToPointer(-1)},
+		{nil, gophercloud.IntToPointer(256)},
+		{gophercloud.IntToPointer(10), gophercloud.IntToPointer(5)},
+	}
+
+	for i, tc := range testCases {
+		_, err := resourcetypes.StringAttribute(false, tc.minLength, tc.maxLength)
+		if err == nil {
+			t.Fatalf("expected an error for the test case %d", i)
+		}
+	}
+
+	_, err := resourcetypes.StringAttribute(false, gophercloud.IntToPointer(0), gophercloud.IntToPointer(255))
+	th.AssertNoErr(t, err)
+}
+
+func TestNumberAttributeInvalidLimits(t *testing.T) {
+	min, max := 10.0, 5.0
+	testCases := []struct {
+		min *float64
+		max *float64
+	}{
+		{&min, &max},
+	}
+
+	for i, tc := range testCases {
+		_, err := resourcetypes.NumberAttribute(false, tc.min, tc.max)
+		if err == nil {
+			t.Fatalf("expected an error for the test case %d", i)
+		}
+	}
+
+	_, err := resourcetypes.NumberAttribute(false, &max, &min)
+	th.AssertNoErr(t, err)
+	_, err = resourcetypes.NumberAttribute(false, &min, &min)
+	th.AssertNoErr(t, err)
+}
+
+func TestTypedAttributesUnmarshal(t *testing.T) {
+	var resourceType resourcetypes.ResourceType
+	err := json.Unmarshal([]byte(ResourceTypeGetWithTypedAttributesResult), &resourceType)
+	th.AssertNoErr(t, err)
+
+	displayName := resourceType.Attributes["display_name"]
+	th.CheckEquals(t, resourcetypes.AttributeTypeString, displayName.Type)
+	th.CheckEquals(t, true, displayName.Required)
+	th.CheckDeepEquals(t, gophercloud.IntToPointer(1), displayName.MinLength)
+	th.CheckDeepEquals(t, gophercloud.IntToPointer(64), displayName.MaxLength)
+
+	minVCPUs := 1.0
+	vcpus := resourceType.Attributes["vcpus"]
+	th.CheckEquals(t, resourcetypes.AttributeTypeNumber, vcpus.Type)
+	th.CheckEquals(t, false, vcpus.Required)
+	th.CheckDeepEquals(t, &minVCPUs, vcpus.Min)
+	if vcpus.Max != nil {
+		t.Fatalf("Expected an unbounded maximum, got %v", *vcpus.Max)
+	}
+
+	th.CheckEquals(t, resourcetypes.AttributeTypeUUID, resourceType.Attributes["image_ref"].Type)
+	th.CheckEquals(t, resourcetypes.AttributeTypeBool, resourceType.Attributes["deleted"].Type)
+	th.CheckEquals(t, resourcetypes.AttributeTypeDatetime, resourceType.Attributes["launched_at"].Type)
+}
@@ -1,6 +1,9 @@
 package testing
 
-import "github.com/gophercloud/utils/gnocchi/metric/v1/resourcetypes"
+import (
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/resourcetypes"
+)
 
 // ResourceTypeListResult represents raw server response from a server to a list call.
 const ResourceTypeListResult = `[
@@ -66,6 +69,9 @@ var ResourceType3 = resourcetypes.ResourceType{
 				"min_length": float64(0),
 				"required":   true,
 			},
+			Required:  true,
+			MinLength: gophercloud.IntToPointer(0),
+			MaxLength: gophercloud.IntToPointer(128),
 		},
 	},
 }
@@ -196,3 +202,148 @@ const ResourceTypeUpdateResult = `
     "name": "identity_project"
 }
 `
+
+// ResourceTypeReconcileGetResult represents a raw server response to a get
+// request of the resource type that needs to be reconciled.
+const ResourceTypeReconcileGetResult = `
+{
+    "attributes": {
+        "port_name": {
+            "max_length": 128,
+            "min_length": 0,
+            "required": false,
+            "type": "string"
+        },
+        "port_id": {
+            "required": true,
+            "type": "uuid"
+        },
+        "legacy_name": {
+            "max_length": 255,
+            "min_length": 0,
+            "required": false,
+            "type": "string"
+        }
+    },
+    "name": "compute_instance_network",
+    "state": "active"
+}
+`
+
+// ResourceTypeReconcileUpdateRequest represents a request to reconcile the
+// resource type from the ResourceTypeReconcileGetResult.
+const ResourceTypeReconcileUpdateRequest = `
+[
+    {
+        "op": "remove",
+        "path": "/attributes/legacy_name"
+    },
+    {
+        "op": "add",
+        "path": "/attributes/enabled",
+        "value": {
+            "options": {
+                "fill": false
+            },
+            "required": true,
+            "type": "bool"
+        }
+    }
+]
+`
+
+// ResourceTypeReconcileUpdateResult represents a raw server response to the
+// ResourceTypeReconcileUpdateRequest.
+const ResourceTypeReconcileUpdateResult = `
+{
+    "attributes": {
+        "port_name": {
+            "max_length": 128,
+            "min_length": 0,
+            "required": false,
+            "type": "string"
+        },
+        "port_id": {
+            "required": true,
+            "type": "uuid"
+        },
+        "enabled": {
+            "required": true,
+            "type": "bool"
+        }
+    },
+    "name": "compute_instance_network",
+    "state": "active"
+}
+`
+
+// ResourceTypeCreateWithTypedAttributesRequest represents a request to create
+// a resource type with attributes of every type.
+const ResourceTypeCreateWithTypedAttributesRequest = `
+{
+    "attributes": {
+        "display_name": {
+            "max_length": 64,
+            "min_length": 1,
+            "required": true,
+            "type": "string",
+            "options": {
+                "fill": "unknown"
+            }
+        },
+        "image_ref": {
+            "required": false,
+            "type": "uuid"
+        },
+        "vcpus": {
+            "min": 1,
+            "required": false,
+            "type": "number"
+        },
+        "deleted": {
+            "required": false,
+            "type": "bool"
+        },
+        "launched_at": {
+            "required": false,
+            "type": "datetime"
+        }
+    },
+    "name": "compute_instance"
+}
+`
+
+// ResourceTypeGetWithTypedAttributesResult represents a raw server response
+// to a request to create a resource type with attributes of every type.
+const ResourceTypeGetWithTypedAttributesResult = `
+{
+    "attributes": {
+        "display_name": {
+            "max_length": 64,
+            "min_length": 1,
+            "required": true,
+            "type": "string"
+        },
+        "image_ref": {
+            "required": false,
+            "type": "uuid"
+        },
+        "vcpus": {
+            "max": null,
+            "min": 1,
+            "required": false,
+            "type": "number"
+        },
+        "deleted": {
+            "required": false,
+            "type": "bool"
+        },
+        "launched_at": {
+            "required": false,
+            "type": "datetime"
+        }
+    },
+    "name": "compute_instance",
+    "state": "active"
+}
+`
@@ -0,0 +1,214 @@
+package testing
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/resourcetypes"
+	fake "github.com/gophercloud/utils/gnocchi/testhelper/client"
+)
+
+// reconcileDesiredOpts represents the desired state of the resource type
+// from the ResourceTypeReconcileGetResult.
+func reconcileDesiredOpts() resourcetypes.CreateOpts {
+	return resourcetypes.CreateOpts{
+		Name: "compute_instance_network",
+		Attributes: map[string]resourcetypes.AttributeOpts{
+			"port_name": {
+				Type: "string",
+				Details: map[string]interface{}{
+					"max_length": 128,
+					"required":   false,
+				},
+			},
+			"port_id": {
+				Type: "uuid",
+				Details: map[string]interface{}{
+					"required": true,
+				},
+			},
+			"enabled": {
+				Type: "bool",
+				Details: map[string]interface{}{
+					"required": true,
+				},
+			},
+		},
+	}
+}
+
+func TestDiff(t *testing.T) {
+	var current resourcetypes.ResourceType
+	th.AssertNoErr(t, json.Unmarshal([]byte(ResourceTypeReconcileGetResult), &current))
+
+	desired := reconcileDesiredOpts()
+	_, err := resourcetypes.Diff(current, desired, resourcetypes.DiffOpts{})
+	if err == nil {
+		t.Fatal("Expected an error for a required attribute without a fill value")
+	}
+
+	opts := resourcetypes.DiffOpts{
+		Fill: map[string]interface{}{
+			"enabled": false,
+		},
+	}
+	actual, err := resourcetypes.Diff(current, desired, opts)
+	th.AssertNoErr(t, err)
+
+	enabledOperation := resourcetypes.AttributeUpdateOpts{
+		Name:      "enabled",
+		Operation: resourcetypes.AttributeAdd,
+		Value: &resourcetypes.AttributeOpts{
+			Type: "bool",
+			Details: map[string]interface{}{
+				"required": true,
+				"options": map[string]interface{}{
+					"fill": false,
+				},
+			},
+		},
+	}
+
+	// Attributes missing in the desired resource type are kept without Prune.
+	expected := []resourcetypes.AttributeUpdateOpts{enabledOperation}
+	th.CheckDeepEquals(t, expected, actual)
+
+	opts.Prune = true
+	actual, err = resourcetypes.Diff(current, desired, opts)
+	th.AssertNoErr(t, err)
+
+	expected = []resourcetypes.AttributeUpdateOpts{
+		{
+			Name:      "legacy_name",
+			Operation: resourcetypes.AttributeRemove,
+		},
+		enabledOperation,
+	}
+	th.CheckDeepEquals(t, expected, actual)
+
+	// Desired options must not be modified.
+	_, ok := desired.Attributes["enabled"].Details["options"]
+	th.CheckEquals(t, false, ok)
+}
+
+func TestDiffChangedAttribute(t *testing.T) {
+	var current resourcetypes.ResourceType
+	th.AssertNoErr(t, json.Unmarshal([]byte(ResourceTypeReconcileGetResult), &current))
+
+	desired := reconcileDesiredOpts()
+	delete(desired.Attributes, "enabled")
+	desired.Attributes["port_name"].Details["max_length"] = 255
+
+	_, err := resourcetypes.Diff(current, desired, resourcetypes.DiffOpts{})
+	if err == nil {
+		t.Fatal("Expected an error for a changed attribute")
+	}
+
+	actual, err := resourcetypes.Diff(current, desired, resourcetypes.DiffOpts{Replace: true})
+	th.AssertNoErr(t, err)
+
+	portNameOpts := desired.Attributes["port_name"]
+	expected := []resourcetypes.AttributeUpdateOpts{
+		{
+			Name:      "port_name",
+			Operation: resourcetypes.AttributeRemove,
+		},
+		{
+			Name:      "port_name",
+			Operation: resourcetypes.AttributeAdd,
+			Value:     &portNameOpts,
+		},
+	}
+	th.CheckDeepEquals(t, expected, actual)
+}
+
+func TestApply(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/resource_type/compute_instance_network", func(w http.ResponseWriter, r *http.Request) {
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		w.Header().Add("Content-Type", "application/json")
+
+		switch r.Method {
+		case "GET":
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, ResourceTypeReconcileGetResult)
+		case "PATCH":
+			th.TestHeader(t, r, "Content-Type", "application/json-patch+json")
+			th.TestJSONRequest(t, r, ResourceTypeReconcileUpdateRequest)
+
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, ResourceTypeReconcileUpdateResult)
+		default:
+			t.Errorf("Unexpected %s request", r.Method)
+		}
+	})
+
+	opts := resourcetypes.ApplyOpts{
+		DiffOpts: resourcetypes.DiffOpts{
+			Fill: map[string]interface{}{
+				"enabled": false,
+			},
+			Prune: true,
+		},
+	}
+	actual, err := resourcetypes.Apply(fake.ServiceClient(), reconcileDesiredOpts(), opts)
+	th.AssertNoErr(t, err)
+
+	th.CheckEquals(t, false, actual.Created)
+	th.CheckEquals(t, 2, len(actual.Operations))
+	th.CheckEquals(t, "bool", actual.ResourceType.Attributes["enabled"].Type)
+	_, ok := actual.ResourceType.Attributes["legacy_name"]
+	th.CheckEquals(t, false, ok)
+}
+
+func TestApplyDryRun(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/resource_type/compute_instance_network", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, ResourceTypeReconcileGetResult)
+	})
+	th.Mux.HandleFunc("/v1/resource_type/compute_instance_disk", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	opts := resourcetypes.ApplyOpts{
+		DiffOpts: resourcetypes.DiffOpts{
+			Fill: map[string]interface{}{
+				"enabled": false,
+			},
+			Prune: true,
+		},
+		DryRun: true,
+	}
+	actual, err := resourcetypes.Apply(fake.ServiceClient(), reconcileDesiredOpts(), opts)
+	th.AssertNoErr(t, err)
+
+	th.CheckEquals(t, false, actual.Created)
+	th.CheckEquals(t, 2, len(actual.Operations))
+	th.CheckEquals(t, resourcetypes.AttributeRemove, actual.Operations[0].Operation)
+	th.CheckEquals(t, resourcetypes.AttributeAdd, actual.Operations[1].Operation)
+	_, ok := actual.ResourceType.Attributes["legacy_name"]
+	th.CheckEquals(t, true, ok)
+
+	desired := reconcileDesiredOpts()
+	desired.Name = "compute_instance_disk"
+	actual, err = resourcetypes.Apply(fake.ServiceClient(), desired, opts)
+	th.AssertNoErr(t, err)
+
+	th.CheckEquals(t, true, actual.Created)
+	th.CheckEquals(t, 0, len(actual.Operations))
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/pagination"
 	th "github.com/gophercloud/gophercloud/testhelper"
 	"github.com/gophercloud/utils/gnocchi/metric/v1/resourcetypes"
@@ -78,6 +79,9 @@ func TestGet(t *testing.T) {
 				"min_length": float64(0),
 				"required":   true,
 			},
+			Required:  true,
+			MinLength: gophercloud.IntToPointer(0),
+			MaxLength: gophercloud.IntToPointer(255),
 		},
 		"image_ref": resourcetypes.Attribute{
 			Type: "uuid",
@@ -164,12 +168,15 @@ func TestCreateWithAttributes(t *testing.T) {
 				"min_length": float64(0),
 				"required":   false,
 			},
+			MinLength: gophercloud.IntToPointer(0),
+			MaxLength: gophercloud.IntToPointer(128),
 		},
 		"port_id": resourcetypes.Attribute{
 			Type: "uuid",
 			Details: map[string]interface{}{
 				"required": true,
 			},
+			Required: true,
 		},
 	})
 }
@@ -236,6 +243,7 @@ func TestUpdate(t *testing.T) {
 			Details: map[string]interface{}{
 				"required": true,
 			},
+			Required: true,
 		},
 		"parent_id": resourcetypes.Attribute{
 			Type: "uuid",
@@ -250,6 +258,9 @@ func TestUpdate(t *testing.T) {
 				"min_length": float64(0),
 				"max_length": float64(128),
 			},
+			Required:  true,
+			MinLength: gophercloud.IntToPointer(0),
+			MaxLength: gophercloud.IntToPointer(128),
 		},
 	})
 }
@@ -0,0 +1,32 @@
+/*
+Package status provides the ability to retrieve Gnocchi service status and
+capabilities through the Gnocchi API.
+
+Example of Getting status of the Gnocchi service
+
+	details := true
+	getOpts := status.GetOpts{
+		Details: &details,
+	}
+	gnocchiStatus, err := status.Get(gnocchiClient, getOpts).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Printf("Measures to process: %d\n", gnocchiStatus.Storage.Summary.Measures)
+	for metricID, measuresCount := range gnocchiStatus.Storage.MeasuresToProcess {
+		fmt.Printf("%s: %d\n", metricID, measuresCount)
+	}
+
+Example of Getting capabilities of the Gnocchi service
+
+	capabilities, err := status.GetCapabilities(gnocchiClient).Extract()
+	if err != nil {
+		panic(err)
+	}
+
+	if !capabilities.HasAggregationMethod("rate:mean") {
+		panic("rate:mean aggregation method is not supported")
+	}
+*/
+package status
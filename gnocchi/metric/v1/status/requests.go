@@ -0,0 +1,45 @@
+package status
+
+import (
+	"github.com/gophercloud/gophercloud"
+)
+
+// GetOptsBuilder allows extensions to add additional parameters to the
+// Get request.
+type GetOptsBuilder interface {
+	ToStatusGetQuery() (string, error)
+}
+
+// GetOpts allows to provide additional options to the Gnocchi status Get request.
+type GetOpts struct {
+	// Details allows to get a count of unprocessed measures for every metric.
+	// Gnocchi returns details by default.
+	Details *bool `q:"details"`
+}
+
+// ToStatusGetQuery formats a GetOpts into a query string.
+func (opts GetOpts) ToStatusGetQuery() (string, error) {
+	q, err := gophercloud.BuildQueryString(opts)
+	return q.String(), err
+}
+
+// Get retrieves status of the Gnocchi service.
+func Get(c *gophercloud.ServiceClient, opts GetOptsBuilder) (r GetResult) {
+	url := getURL(c)
+	if opts != nil {
+		query, err := opts.ToStatusGetQuery()
+		if err != nil {
+			r.Err = err
+			return
+		}
+		url += query
+	}
+	_, r.Err = c.Get(url, &r.Body, nil)
+	return
+}
+
+// GetCapabilities retrieves capabilities of the Gnocchi service.
+func GetCapabilities(c *gophercloud.ServiceClient) (r GetCapabilitiesResult) {
+	_, r.Err = c.Get(capabilitiesURL(c), &r.Body, nil)
+	return
+}
@@ -0,0 +1,87 @@
+package status
+
+import (
+	"github.com/gophercloud/gophercloud"
+)
+
+// GetResult represents the result of a get operation. Call its Extract
+// method to interpret it as a Gnocchi status.
+type GetResult struct {
+	gophercloud.Result
+}
+
+// Extract is a function that accepts a result and extracts a Gnocchi status.
+func (r GetResult) Extract() (*Status, error) {
+	var s *Status
+	err := r.ExtractInto(&s)
+	return s, err
+}
+
+// GetCapabilitiesResult represents the result of a get capabilities operation.
+// Call its Extract method to interpret it as Gnocchi capabilities.
+type GetCapabilitiesResult struct {
+	gophercloud.Result
+}
+
+// Extract is a function that accepts a result and extracts Gnocchi capabilities.
+func (r GetCapabilitiesResult) Extract() (*Capabilities, error) {
+	var s *Capabilities
+	err := r.ExtractInto(&s)
+	return s, err
+}
+
+// Status represents a status of the Gnocchi service.
+type Status struct {
+	// Storage contains information about measures that weren't processed yet.
+	Storage Storage `json:"storage"`
+
+	// Metricd contains information about Gnocchi metricd daemons.
+	Metricd Metricd `json:"metricd"`
+}
+
+// Storage represents a backlog of the Gnocchi incoming measures storage.
+type Storage struct {
+	// Summary contains total counts of the unprocessed measures.
+	Summary StorageSummary `json:"summary"`
+
+	// MeasuresToProcess is a count of unprocessed measures keyed by a metric ID.
+	// It's populated only if the status was requested with details.
+	MeasuresToProcess map[string]int `json:"measures_to_process"`
+}
+
+// StorageSummary represents total counts of the unprocessed measures.
+type StorageSummary struct {
+	// Metrics is a count of metrics that have unprocessed measures.
+	Metrics int `json:"metrics"`
+
+	// Measures is a count of unprocessed measures.
+	Measures int `json:"measures"`
+}
+
+// Metricd represents Gnocchi metricd daemons status.
+type Metricd struct {
+	// Processors is a list of active metricd processors.
+	Processors []string `json:"processors"`
+}
+
+// Capabilities represents capabilities of the Gnocchi service.
+type Capabilities struct {
+	// AggregationMethods is a list of aggregation methods that can be used in
+	// archive policies and measures requests.
+	AggregationMethods []string `json:"aggregation_methods"`
+
+	// DynamicAggregationMethods is a list of aggregation methods that can be
+	// used to aggregate measures across several metrics.
+	DynamicAggregationMethods []string `json:"dynamic_aggregation_methods"`
+}
+
+// HasAggregationMethod checks whether the provided aggregation method is
+// supported by the Gnocchi service.
+func (c Capabilities) HasAggregationMethod(method string) bool {
+	for _, m := range c.AggregationMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
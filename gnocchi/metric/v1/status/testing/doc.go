@@ -0,0 +1,2 @@
+// status unit tests
+package testing
@@ -0,0 +1,75 @@
+package testing
+
+import "github.com/gophercloud/utils/gnocchi/metric/v1/status"
+
+// StatusGetResult represents a raw server response from a server to a get request.
+const StatusGetResult = `
+{
+    "metricd": {
+        "processors": [
+            "node-1.0.6eba7fd6-cb0d-4e6e-a8b0-a3c2fd8e49da",
+            "node-2.0.a97d7c5c-8c0a-4ec9-b8bb-6e1db0bd6c1a"
+        ]
+    },
+    "storage": {
+        "measures_to_process": {
+            "01b2953e-de74-448a-a305-c84440697933": 12,
+            "9e5a6441-1044-4181-b66e-34e180753040": 3
+        },
+        "summary": {
+            "measures": 15,
+            "metrics": 2
+        }
+    }
+}
+`
+
+// GetStatusExpected represents an expected response from a get request.
+var GetStatusExpected = &status.Status{
+	Storage: status.Storage{
+		Summary: status.StorageSummary{
+			Metrics:  2,
+			Measures: 15,
+		},
+		MeasuresToProcess: map[string]int{
+			"01b2953e-de74-448a-a305-c84440697933": 12,
+			"9e5a6441-1044-4181-b66e-34e180753040": 3,
+		},
+	},
+	Metricd: status.Metricd{
+		Processors: []string{
+			"node-1.0.6eba7fd6-cb0d-4e6e-a8b0-a3c2fd8e49da",
+			"node-2.0.a97d7c5c-8c0a-4ec9-b8bb-6e1db0bd6c1a",
+		},
+	},
+}
+
+// CapabilitiesGetResult represents a raw server response from a server to a get capabilities request.
+const CapabilitiesGetResult = `
+{
+    "aggregation_methods": [
+        "mean",
+        "max",
+        "min",
+        "rate:mean"
+    ],
+    "dynamic_aggregation_methods": [
+        "mean",
+        "sum"
+    ]
+}
+`
+
+// GetCapabilitiesExpected represents an expected response from a get capabilities request.
+var GetCapabilitiesExpected = &status.Capabilities{
+	AggregationMethods: []string{
+		"mean",
+		"max",
+		"min",
+		"rate:mean",
+	},
+	DynamicAggregationMethods: []string{
+		"mean",
+		"sum",
+	},
+}
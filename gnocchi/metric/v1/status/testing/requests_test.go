@@ -0,0 +1,59 @@
+package testing
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/status"
+	fake "github.com/gophercloud/utils/gnocchi/testhelper/client"
+)
+
+func TestGetStatus(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/status", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+		th.TestFormValues(t, r, map[string]string{
+			"details": "true",
+		})
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprintf(w, StatusGetResult)
+	})
+
+	details := true
+	opts := status.GetOpts{
+		Details: &details,
+	}
+	s, err := status.Get(fake.ServiceClient(), opts).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, GetStatusExpected, s)
+}
+
+func TestGetCapabilities(t *testing.T) {
+	th.SetupHTTP()
+	defer th.TeardownHTTP()
+
+	th.Mux.HandleFunc("/v1/capabilities", func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "GET")
+		th.TestHeader(t, r, "X-Auth-Token", fake.TokenID)
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprintf(w, CapabilitiesGetResult)
+	})
+
+	s, err := status.GetCapabilities(fake.ServiceClient()).Extract()
+	th.AssertNoErr(t, err)
+	th.CheckDeepEquals(t, GetCapabilitiesExpected, s)
+
+	th.AssertEquals(t, true, s.HasAggregationMethod("rate:mean"))
+	th.AssertEquals(t, false, s.HasAggregationMethod("rate:max"))
+}
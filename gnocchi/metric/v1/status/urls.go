@@ -0,0 +1,16 @@
+package status
+
+import "github.com/gophercloud/gophercloud"
+
+const (
+	statusPath       = "status"
+	capabilitiesPath = "capabilities"
+)
+
+func getURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL(statusPath)
+}
+
+func capabilitiesURL(c *gophercloud.ServiceClient) string {
+	return c.ServiceURL(capabilitiesPath)
+}
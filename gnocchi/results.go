@@ -3,7 +3,12 @@ package gnocchi
 import (
 	"bytes"
 	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/gophercloud/gophercloud/pagination"
 )
 
 // RFC3339NanoTimezone describes a common timestamp format used by Gnocchi API responses.
@@ -35,3 +40,51 @@ func (jt *JSONRFC3339NanoTimezone) UnmarshalJSON(data []byte) error {
 	*jt = JSONRFC3339NanoTimezone(t)
 	return nil
 }
+
+// NextPageURL returns a URL of the next page of a Gnocchi collection that is
+// paginated with the "limit" and "marker" query parameters.
+//
+// Gnocchi provides the "next" link in the Link header of a response if the
+// page was filled up to the limit, so that link is used if it's present.
+// Otherwise the marker of the last item on the page is used if the page
+// contains as many items as it was requested with the "limit" parameter.
+// An empty URL is returned for the last page.
+func NextPageURL(page pagination.MarkerPageBase, count int) (string, error) {
+	if next := NextLink(page.Header); next != "" {
+		return next, nil
+	}
+
+	limit, err := strconv.Atoi(page.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 || count < limit {
+		return "", nil
+	}
+
+	return page.NextPageURL()
+}
+
+// NextLink parses the Link header of a Gnocchi response in a such format:
+//
+//	<http://gnocchi/v1/metric?limit=2&marker=...&sort=id%3Aasc>; rel="next"
+//
+// and returns the URL of a link with the "next" relation. An empty string is
+// returned if there is no such link.
+func NextLink(header http.Header) string {
+	for _, value := range header["Link"] {
+		for _, link := range strings.Split(value, ",") {
+			parts := strings.Split(link, ";")
+			url := strings.TrimSpace(parts[0])
+			if !strings.HasPrefix(url, "<") || !strings.HasSuffix(url, ">") {
+				continue
+			}
+
+			for _, param := range parts[1:] {
+				param = strings.Replace(strings.TrimSpace(param), " ", "", -1)
+				if param == `rel="next"` || param == "rel=next" {
+					return strings.TrimSuffix(strings.TrimPrefix(url, "<"), ">")
+				}
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,165 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gophercloud/utils/gnocchi"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/archivepolicies"
+)
+
+// defaultArchivePolicies returns archive policies that Gnocchi creates by default.
+func defaultArchivePolicies() []archivepolicies.ArchivePolicy {
+	day := 24 * time.Hour
+	policies := map[string][][2]time.Duration{
+		"low": {
+			{5 * time.Minute, 30 * day},
+		},
+		"medium": {
+			{time.Minute, 7 * day},
+			{time.Hour, 365 * day},
+		},
+		"high": {
+			{time.Second, time.Hour},
+			{time.Minute, 7 * day},
+			{time.Hour, 365 * day},
+		},
+	}
+
+	var result []archivepolicies.ArchivePolicy
+	for name, definitions := range policies {
+		ap := archivepolicies.ArchivePolicy{
+			Name:               name,
+			AggregationMethods: archivepolicies.DefaultAggregationMethods,
+		}
+		for _, d := range definitions {
+			ap.Definition = append(ap.Definition, archivepolicies.ArchivePolicyDefinition{
+				Granularity: gnocchi.Duration(d[0]),
+				Points:      int(d[1] / d[0]),
+				TimeSpan:    gnocchi.Duration(d[1]),
+			})
+		}
+		result = append(result, ap)
+	}
+
+	return result
+}
+
+// serveArchivePolicies handles requests to the "/v1/archive_policy" path.
+func (s *Server) serveArchivePolicies(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == "GET":
+		names := make([]string, 0, len(s.archivePolicies))
+		for name := range s.archivePolicies {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		result := make([]*archivepolicies.ArchivePolicy, len(names))
+		for i, name := range names {
+			result[i] = s.archivePolicies[name]
+		}
+		writeJSON(w, http.StatusOK, result)
+	case len(parts) == 0 && r.Method == "POST":
+		s.createArchivePolicy(w, r)
+	case len(parts) == 1:
+		ap, ok := s.archivePolicies[parts[0]]
+		if !ok {
+			writeError(w, http.StatusNotFound, "archive policy %s does not exist", parts[0])
+			return
+		}
+
+		switch r.Method {
+		case "GET":
+			writeJSON(w, http.StatusOK, ap)
+		case "PATCH":
+			s.updateArchivePolicy(w, r, ap)
+		case "DELETE":
+			for _, m := range s.metrics {
+				if m.ArchivePolicyName == ap.Name {
+					writeError(w, http.StatusBadRequest, "archive policy %s is still in use", ap.Name)
+					return
+				}
+			}
+			delete(s.archivePolicies, ap.Name)
+			writeNoContent(w)
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+// createArchivePolicy handles archive policy create requests.
+func (s *Server) createArchivePolicy(w http.ResponseWriter, r *http.Request) {
+	var opts archivepolicies.CreateOpts
+	if err := decodeBody(r, &opts); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid archive policy: %s", err)
+		return
+	}
+	if opts.Name == "" {
+		writeError(w, http.StatusBadRequest, "missing archive policy name")
+		return
+	}
+	if _, ok := s.archivePolicies[opts.Name]; ok {
+		writeError(w, http.StatusConflict, "archive policy %s already exists", opts.Name)
+		return
+	}
+
+	estimate, err := archivepolicies.EstimateStorage(opts)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid archive policy: %s", err)
+		return
+	}
+
+	ap := &archivepolicies.ArchivePolicy{
+		Name:               opts.Name,
+		BackWindow:         opts.BackWindow,
+		AggregationMethods: estimate.AggregationMethods,
+		Definition:         estimate.Definition,
+	}
+	s.archivePolicies[ap.Name] = ap
+
+	writeJSON(w, http.StatusCreated, ap)
+}
+
+// updateArchivePolicy handles archive policy update requests. Gnocchi only
+// allows to change timespans of existing granularities.
+func (s *Server) updateArchivePolicy(w http.ResponseWriter, r *http.Request, ap *archivepolicies.ArchivePolicy) {
+	var opts archivepolicies.UpdateOpts
+	if err := decodeBody(r, &opts); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid archive policy: %s", err)
+		return
+	}
+
+	estimate, err := archivepolicies.EstimateStorage(archivepolicies.CreateOpts{
+		Name:               ap.Name,
+		BackWindow:         ap.BackWindow,
+		AggregationMethods: ap.AggregationMethods,
+		Definition:         opts.Definition,
+	})
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid archive policy: %s", err)
+		return
+	}
+
+	granularities := make(map[gnocchi.Duration]bool, len(ap.Definition))
+	for _, d := range ap.Definition {
+		granularities[d.Granularity] = true
+	}
+	for _, d := range estimate.Definition {
+		if !granularities[d.Granularity] {
+			writeError(w, http.StatusBadRequest, "archive policy %s does not support change: granularity %s isn't defined", ap.Name, d.Granularity)
+			return
+		}
+	}
+	if len(estimate.Definition) != len(ap.Definition) {
+		writeError(w, http.StatusBadRequest, "archive policy %s does not support change: granularities can't be removed", ap.Name)
+		return
+	}
+
+	ap.Definition = estimate.Definition
+	writeJSON(w, http.StatusOK, ap)
+}
@@ -0,0 +1,38 @@
+/*
+Package server provides a stateful in-memory fake of the Gnocchi v1 API that
+can be driven with the gnocchi/metric/v1/* packages in tests.
+
+The Server stores archive policies, resource types, resources, metrics and
+their measures. Measures are aggregated on read according to the archive
+policy of the metric with mean, sum, min, max, count, first, last, median,
+std and percentile aggregation methods and their "rate:*" variants. Search,
+aggregates, archive policy rules and the "fill" and "transform" parameters
+of measures aren't supported.
+
+Example of Using the Server in a test
+
+	srv := server.New()
+	defer srv.Close()
+
+	client := srv.ServiceClient()
+	metric, err := metrics.Create(client, metrics.CreateOpts{
+		ArchivePolicyName: "low",
+	}).Extract()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	timestamp := time.Date(2018, 1, 10, 12, 0, 0, 0, time.UTC)
+	err = measures.Create(client, metric.ID, measures.CreateOpts{
+		Measures: []measures.MeasureOpts{
+			{
+				Timestamp: &timestamp,
+				Value:     42,
+			},
+		},
+	}).ExtractErr()
+	if err != nil {
+		t.Fatal(err)
+	}
+*/
+package server
@@ -0,0 +1,480 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gophercloud/utils/gnocchi"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/archivepolicies"
+)
+
+// measureBody represents a single measure of the measures create requests.
+type measureBody struct {
+	Timestamp interface{} `json:"timestamp"`
+	Value     float64     `json:"value"`
+}
+
+// point represents a single aggregated measure.
+type point struct {
+	Timestamp time.Time
+	Value     float64
+}
+
+// parseMeasures validates measures from the request body.
+func parseMeasures(body []measureBody) (map[time.Time]float64, error) {
+	measures := make(map[time.Time]float64, len(body))
+	for _, measure := range body {
+		timestamp, err := parseTimestamp(measure.Timestamp)
+		if err != nil {
+			return nil, err
+		}
+		measures[timestamp] = measure.Value
+	}
+	return measures, nil
+}
+
+// addMeasures stores raw measures of the metric. Gnocchi keeps only the last
+// value of measures with the same timestamp.
+func (m *metric) addMeasures(measures map[time.Time]float64) {
+	for timestamp, value := range measures {
+		m.measures[timestamp] = value
+	}
+}
+
+// serveMeasures handles requests to measures of the metric.
+func (s *Server) serveMeasures(w http.ResponseWriter, r *http.Request, m *metric) {
+	switch r.Method {
+	case "GET":
+		s.listMeasures(w, r, m)
+	case "POST":
+		var body []measureBody
+		if err := decodeBody(r, &body); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid measures: %s", err)
+			return
+		}
+		measures, err := parseMeasures(body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid measures: %s", err)
+			return
+		}
+		m.addMeasures(measures)
+		writeAccepted(w)
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+// listMeasures aggregates raw measures of the metric according to its archive
+// policy and the query parameters.
+func (s *Server) listMeasures(w http.ResponseWriter, r *http.Request, m *metric) {
+	query := r.URL.Query()
+	for _, param := range []string{"fill", "transform"} {
+		if query.Get(param) != "" {
+			writeError(w, http.StatusNotImplemented, "the %s parameter isn't supported by the fake Gnocchi server", param)
+			return
+		}
+	}
+
+	ap := s.archivePolicies[m.ArchivePolicyName]
+
+	aggregation := query.Get("aggregation")
+	if aggregation == "" {
+		aggregation = "mean"
+	}
+	if err := archivepolicies.ValidateAggregationMethod(aggregation); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid aggregation value: %s", aggregation)
+		return
+	}
+	if !hasAggregationMethod(ap, aggregation) {
+		writeError(w, http.StatusNotFound, "aggregation method '%s' for metric %s does not exist", aggregation, m.ID)
+		return
+	}
+
+	var start, stop *time.Time
+	for _, param := range []string{"start", "stop"} {
+		v := query.Get(param)
+		if v == "" {
+			continue
+		}
+		t, err := parseTimestamp(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid value for '%s': %s", param, err)
+			return
+		}
+		if param == "start" {
+			start = &t
+		} else {
+			stop = &t
+		}
+	}
+
+	definitions := make([]archivepolicies.ArchivePolicyDefinition, len(ap.Definition))
+	copy(definitions, ap.Definition)
+	if v := query.Get("granularity"); v != "" {
+		granularity, err := gnocchi.ParseDuration(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid value for 'granularity': %s", err)
+			return
+		}
+
+		definitions = definitions[:0]
+		for _, d := range ap.Definition {
+			if d.Granularity == granularity {
+				definitions = append(definitions, d)
+			}
+		}
+		if len(definitions) == 0 {
+			writeError(w, http.StatusNotFound, "granularity '%s' for metric %s does not exist", granularity.QueryString(), m.ID)
+			return
+		}
+	}
+
+	var resample gnocchi.Duration
+	if v := query.Get("resample"); v != "" {
+		if query.Get("granularity") == "" {
+			writeError(w, http.StatusBadRequest, "a granularity must be specified to resample")
+			return
+		}
+		var err error
+		if resample, err = gnocchi.ParseDuration(v); err != nil || resample <= 0 {
+			writeError(w, http.StatusBadRequest, "invalid value for 'resample': %s", v)
+			return
+		}
+	}
+
+	limit := -1
+	if v := query.Get("limit"); v != "" {
+		var err error
+		if limit, err = strconv.Atoi(v); err != nil || limit < 0 {
+			writeError(w, http.StatusBadRequest, "invalid value for 'limit': %s", v)
+			return
+		}
+	}
+
+	// Gnocchi returns measures of the coarsest granularity first.
+	sort.Slice(definitions, func(i, j int) bool {
+		return definitions[i].Granularity > definitions[j].Granularity
+	})
+
+	raw := make([]point, 0, len(m.measures))
+	for timestamp, value := range m.measures {
+		raw = append(raw, point{Timestamp: timestamp, Value: value})
+	}
+
+	// The "rate:*" methods aggregate differences between consecutive
+	// measures with the base method.
+	method := strings.TrimPrefix(aggregation, archivepolicies.RateAggregationPrefix)
+	if method != aggregation {
+		raw = rate(raw)
+	}
+
+	result := make([][]interface{}, 0)
+	for _, d := range definitions {
+		granularity := d.Granularity
+		points := aggregate(raw, granularity, method)
+		if len(points) > d.Points {
+			points = points[len(points)-d.Points:]
+		}
+
+		// Gnocchi resamples aggregates from the requested time range.
+		filtered := points[:0]
+		for _, p := range points {
+			if start != nil && p.Timestamp.Before(*start) || stop != nil && !p.Timestamp.Before(*stop) {
+				continue
+			}
+			filtered = append(filtered, p)
+		}
+		points = filtered
+		if resample != 0 {
+			granularity = resample
+			points = aggregate(points, granularity, method)
+		}
+
+		for _, p := range points {
+			if math.IsNaN(p.Value) {
+				continue
+			}
+			result = append(result, []interface{}{formatTimestamp(p.Timestamp), granularity.Seconds(), p.Value})
+		}
+	}
+
+	if limit >= 0 && limit < len(result) {
+		result = result[:limit]
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// hasAggregationMethod checks that Gnocchi computes the aggregation method
+// for metrics of the archive policy.
+func hasAggregationMethod(ap *archivepolicies.ArchivePolicy, aggregation string) bool {
+	for _, method := range ap.AggregationMethods {
+		if method == aggregation {
+			return true
+		}
+	}
+	return false
+}
+
+// rate returns differences between values of consecutive points at the
+// timestamps of the later points.
+func rate(points []point) []point {
+	if len(points) < 2 {
+		return nil
+	}
+
+	sorted := make([]point, len(points))
+	copy(sorted, points)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Timestamp.Before(sorted[j].Timestamp) })
+
+	result := make([]point, len(sorted)-1)
+	for i := 1; i < len(sorted); i++ {
+		result[i-1] = point{
+			Timestamp: sorted[i].Timestamp,
+			Value:     sorted[i].Value - sorted[i-1].Value,
+		}
+	}
+	return result
+}
+
+// aggregate groups points into buckets aligned to the Unix epoch and
+// aggregates values of every bucket. It returns points ordered by timestamps.
+func aggregate(points []point, granularity gnocchi.Duration, aggregation string) []point {
+	step := int64(granularity)
+	buckets := make(map[int64][]point)
+	for _, p := range points {
+		ns := p.Timestamp.UnixNano()
+		key := ns - ns%step
+		if ns%step < 0 {
+			key -= step
+		}
+		buckets[key] = append(buckets[key], p)
+	}
+
+	keys := make([]int64, 0, len(buckets))
+	for key := range buckets {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	result := make([]point, len(keys))
+	for i, key := range keys {
+		bucket := buckets[key]
+		sort.Slice(bucket, func(i, j int) bool { return bucket[i].Timestamp.Before(bucket[j].Timestamp) })
+		values := make([]float64, len(bucket))
+		for j, p := range bucket {
+			values[j] = p.Value
+		}
+		result[i] = point{
+			Timestamp: time.Unix(0, key).UTC(),
+			Value:     aggregateValues(values, aggregation),
+		}
+	}
+
+	return result
+}
+
+// aggregateValues applies the aggregation method to values ordered by their
+// timestamps. It returns NaN if the method can't be applied.
+func aggregateValues(values []float64, aggregation string) float64 {
+	switch aggregation {
+	case "first":
+		return values[0]
+	case "last":
+		return values[len(values)-1]
+	case "count":
+		return float64(len(values))
+	case "sum", "mean":
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		if aggregation == "mean" {
+			return sum / float64(len(values))
+		}
+		return sum
+	case "min", "max":
+		result := values[0]
+		for _, v := range values[1:] {
+			if aggregation == "min" && v < result || aggregation == "max" && v > result {
+				result = v
+			}
+		}
+		return result
+	case "std":
+		// Gnocchi computes the sample standard deviation.
+		if len(values) < 2 {
+			return math.NaN()
+		}
+		mean := aggregateValues(values, "mean")
+		var sum float64
+		for _, v := range values {
+			sum += (v - mean) * (v - mean)
+		}
+		return math.Sqrt(sum / float64(len(values)-1))
+	case "median":
+		return percentile(values, 50)
+	}
+
+	if strings.HasSuffix(aggregation, "pct") {
+		if q, err := strconv.ParseFloat(strings.TrimSuffix(aggregation, "pct"), 64); err == nil {
+			return percentile(values, q)
+		}
+	}
+
+	return math.NaN()
+}
+
+// percentile computes the q-th percentile of values with the linear
+// interpolation between the closest ranks.
+func percentile(values []float64, q float64) float64 {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	rank := q / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
+// serveBatch handles requests to the "/v1/batch" path.
+func (s *Server) serveBatch(w http.ResponseWriter, r *http.Request, parts []string) {
+	if r.Method != "POST" {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	switch strings.Join(parts, "/") {
+	case "metrics/measures":
+		s.batchMetricsMeasures(w, r)
+	case "resources/metrics/measures":
+		s.batchResourcesMetricsMeasures(w, r)
+	default:
+		writeError(w, http.StatusNotImplemented, "the %s path isn't supported by the fake Gnocchi server", r.URL.Path)
+	}
+}
+
+// batchMetricsMeasures handles requests to create measures of several
+// metrics referenced by their IDs.
+func (s *Server) batchMetricsMeasures(w http.ResponseWriter, r *http.Request) {
+	var body map[string][]measureBody
+	if err := decodeBody(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid measures: %s", err)
+		return
+	}
+
+	var unknown []string
+	measures := make(map[string]map[time.Time]float64, len(body))
+	for metricID, metricMeasures := range body {
+		if _, ok := s.metrics[metricID]; !ok {
+			unknown = append(unknown, metricID)
+			continue
+		}
+
+		var err error
+		if measures[metricID], err = parseMeasures(metricMeasures); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid measures of the metric %s: %s", metricID, err)
+			return
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		writeError(w, http.StatusBadRequest, "unknown metrics: %s", strings.Join(unknown, ", "))
+		return
+	}
+
+	for metricID, metricMeasures := range measures {
+		s.metrics[metricID].addMeasures(metricMeasures)
+	}
+
+	writeAccepted(w)
+}
+
+// batchResourcesMetricsMeasures handles requests to create measures of
+// several metrics referenced by their resources and names. Missing metrics
+// are created if the "create_metrics" query parameter is true.
+func (s *Server) batchResourcesMetricsMeasures(w http.ResponseWriter, r *http.Request) {
+	createMetrics, _ := strconv.ParseBool(r.URL.Query().Get("create_metrics"))
+
+	var body map[string]map[string]json.RawMessage
+	if err := decodeBody(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid measures: %s", err)
+		return
+	}
+
+	// Validate the whole batch before any change.
+	type metricMeasures struct {
+		resource *resource
+		name     string
+		metric   *metric
+		measures map[time.Time]float64
+	}
+	var batch []metricMeasures
+	var unknown []string
+
+	for resourceID, metrics := range body {
+		res, ok := s.resources[resourceID]
+		if !ok {
+			unknown = append(unknown, resourceID)
+			continue
+		}
+
+		for name, raw := range metrics {
+			var opts struct {
+				ArchivePolicyName string        `json:"archive_policy_name"`
+				Unit              string        `json:"unit"`
+				Measures          []measureBody `json:"measures"`
+			}
+			if err := json.Unmarshal(raw, &opts.Measures); err != nil {
+				if err := json.Unmarshal(raw, &opts); err != nil {
+					writeError(w, http.StatusBadRequest, "invalid measures of the metric %s of the resource %s: %s", name, resourceID, err)
+					return
+				}
+			}
+
+			item := metricMeasures{resource: res, name: name}
+			if metricID, ok := res.Metrics[name]; ok {
+				item.metric = s.metrics[metricID]
+			} else if createMetrics {
+				m, err := s.newMetric(name, opts.ArchivePolicyName, opts.Unit)
+				if err != nil {
+					writeError(w, http.StatusBadRequest, "invalid metric %s of the resource %s: %s", name, resourceID, err)
+					return
+				}
+				item.metric = m
+			} else {
+				unknown = append(unknown, fmt.Sprintf("%s/%s", resourceID, name))
+				continue
+			}
+
+			var err error
+			if item.measures, err = parseMeasures(opts.Measures); err != nil {
+				writeError(w, http.StatusBadRequest, "invalid measures of the metric %s of the resource %s: %s", name, resourceID, err)
+				return
+			}
+			batch = append(batch, item)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		writeError(w, http.StatusBadRequest, "unknown resources or metrics: %s", strings.Join(unknown, ", "))
+		return
+	}
+
+	for _, item := range batch {
+		if _, ok := s.metrics[item.metric.ID]; !ok {
+			s.linkResourceMetrics(item.resource, map[string]*metric{item.name: item.metric})
+		}
+		item.metric.addMeasures(item.measures)
+	}
+
+	writeAccepted(w)
+}
@@ -0,0 +1,176 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// metric represents a stored Gnocchi metric.
+type metric struct {
+	ID                string
+	Name              string
+	ResourceID        string
+	Unit              string
+	ArchivePolicyName string
+
+	// measures contains raw measures of the metric keyed by their timestamps.
+	measures map[time.Time]float64
+}
+
+// newMetric prepares a new metric that uses an existing archive policy.
+// It doesn't store the metric.
+func (s *Server) newMetric(name, archivePolicyName, unit string) (*metric, error) {
+	if archivePolicyName == "" {
+		return nil, fmt.Errorf("no archive policy name specified for the metric %s", name)
+	}
+	if _, ok := s.archivePolicies[archivePolicyName]; !ok {
+		return nil, fmt.Errorf("archive policy %s does not exist", archivePolicyName)
+	}
+
+	return &metric{
+		ID:                newUUID(),
+		Name:              name,
+		Unit:              unit,
+		ArchivePolicyName: archivePolicyName,
+		measures:          make(map[time.Time]float64),
+	}, nil
+}
+
+// metricMap builds a representation of the metric for Gnocchi responses.
+// The attached resource is included if withResource is true.
+func (s *Server) metricMap(m *metric, withResource bool) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":                    m.ID,
+		"name":                  optionalString(m.Name),
+		"unit":                  optionalString(m.Unit),
+		"resource_id":           optionalString(m.ResourceID),
+		"archive_policy_name":   m.ArchivePolicyName,
+		"archive_policy":        s.archivePolicies[m.ArchivePolicyName],
+		"created_by_project_id": ProjectID,
+		"created_by_user_id":    UserID,
+		"creator":               UserID + ":" + ProjectID,
+	}
+
+	if res, ok := s.resources[m.ResourceID]; ok && withResource {
+		result["resource"] = res.toMap()
+	}
+
+	return result
+}
+
+// serveMetrics handles requests to the "/v1/metric" path.
+func (s *Server) serveMetrics(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == "GET":
+		ids := make([]string, 0, len(s.metrics))
+		for id := range s.metrics {
+			ids = append(ids, id)
+		}
+
+		ids, err := paginate(r, ids)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "%s", err)
+			return
+		}
+
+		result := make([]map[string]interface{}, len(ids))
+		for i, id := range ids {
+			result[i] = s.metricMap(s.metrics[id], false)
+		}
+		writeJSON(w, http.StatusOK, result)
+	case len(parts) == 0 && r.Method == "POST":
+		s.createMetric(w, r)
+	case len(parts) == 1 || len(parts) == 2 && parts[1] == "measures":
+		m, ok := s.metrics[parts[0]]
+		if !ok {
+			writeError(w, http.StatusNotFound, "metric %s does not exist", parts[0])
+			return
+		}
+
+		switch {
+		case len(parts) == 2:
+			s.serveMeasures(w, r, m)
+		case r.Method == "GET":
+			writeJSON(w, http.StatusOK, s.metricMap(m, true))
+		case r.Method == "PATCH":
+			s.updateMetric(w, r, m)
+		case r.Method == "DELETE":
+			if res, ok := s.resources[m.ResourceID]; ok {
+				delete(res.Metrics, m.Name)
+			}
+			delete(s.metrics, m.ID)
+			writeNoContent(w)
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+// createMetric handles metric create requests. The metric is attached to the
+// resource if both resource ID and name are provided.
+func (s *Server) createMetric(w http.ResponseWriter, r *http.Request) {
+	var opts struct {
+		ArchivePolicyName string `json:"archive_policy_name"`
+		Name              string `json:"name"`
+		ResourceID        string `json:"resource_id"`
+		Unit              string `json:"unit"`
+	}
+	if err := decodeBody(r, &opts); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid metric: %s", err)
+		return
+	}
+
+	m, err := s.newMetric(opts.Name, opts.ArchivePolicyName, opts.Unit)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid metric: %s", err)
+		return
+	}
+
+	if opts.ResourceID != "" {
+		res, ok := s.resources[opts.ResourceID]
+		if !ok {
+			writeError(w, http.StatusBadRequest, "invalid metric: resource %s does not exist", opts.ResourceID)
+			return
+		}
+		if opts.Name == "" {
+			writeError(w, http.StatusBadRequest, "invalid metric: a name is required to attach the metric to the resource %s", res.ID)
+			return
+		}
+		if _, ok := res.Metrics[opts.Name]; ok {
+			writeError(w, http.StatusConflict, "metric %s of the resource %s already exists", opts.Name, res.ID)
+			return
+		}
+		s.linkResourceMetrics(res, map[string]*metric{opts.Name: m})
+	}
+	s.metrics[m.ID] = m
+
+	writeJSON(w, http.StatusCreated, s.metricMap(m, false))
+}
+
+// updateMetric handles metric update requests.
+func (s *Server) updateMetric(w http.ResponseWriter, r *http.Request, m *metric) {
+	var opts struct {
+		ArchivePolicyName *string `json:"archive_policy_name"`
+		Unit              *string `json:"unit"`
+	}
+	if err := decodeBody(r, &opts); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid metric: %s", err)
+		return
+	}
+
+	if opts.ArchivePolicyName != nil {
+		if _, ok := s.archivePolicies[*opts.ArchivePolicyName]; !ok {
+			writeError(w, http.StatusBadRequest, "invalid metric: archive policy %s does not exist", *opts.ArchivePolicyName)
+			return
+		}
+		m.ArchivePolicyName = *opts.ArchivePolicyName
+	}
+	if opts.Unit != nil {
+		m.Unit = *opts.Unit
+	}
+
+	writeJSON(w, http.StatusOK, s.metricMap(m, true))
+}
@@ -0,0 +1,423 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gophercloud/utils/gnocchi/metric/v1/resources"
+)
+
+// resource represents a stored Gnocchi resource.
+type resource struct {
+	ID                 string
+	Type               string
+	OriginalResourceID string
+	ProjectID          string
+	UserID             string
+	StartedAt          time.Time
+	EndedAt            *time.Time
+	RevisionStart      time.Time
+	Metrics            map[string]string
+	ExtraAttributes    map[string]interface{}
+
+	// revisions contains representations of previous resource revisions.
+	revisions []map[string]interface{}
+}
+
+// toMap builds a representation of the resource for Gnocchi responses.
+func (res *resource) toMap() map[string]interface{} {
+	m := make(map[string]interface{}, len(res.ExtraAttributes)+13)
+	for k, v := range res.ExtraAttributes {
+		m[k] = v
+	}
+
+	metrics := make(map[string]string, len(res.Metrics))
+	for k, v := range res.Metrics {
+		metrics[k] = v
+	}
+
+	m["id"] = res.ID
+	m["type"] = res.Type
+	m["original_resource_id"] = res.OriginalResourceID
+	m["project_id"] = optionalString(res.ProjectID)
+	m["user_id"] = optionalString(res.UserID)
+	m["created_by_project_id"] = ProjectID
+	m["created_by_user_id"] = UserID
+	m["creator"] = UserID + ":" + ProjectID
+	m["started_at"] = formatTimestamp(res.StartedAt)
+	m["ended_at"] = formatOptionalTimestamp(res.EndedAt)
+	m["revision_start"] = formatTimestamp(res.RevisionStart)
+	m["revision_end"] = nil
+	m["metrics"] = metrics
+
+	return m
+}
+
+// optionalString converts empty strings into JSON nulls.
+func optionalString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// resourceFields contains attributes of every resource that aren't stored
+// as extra attributes.
+var resourceFields = []string{
+	"id", "type", "original_resource_id", "project_id", "user_id",
+	"created_by_project_id", "created_by_user_id", "creator",
+	"started_at", "ended_at", "revision_start", "revision_end", "metrics",
+}
+
+// serveResources handles requests to the "/v1/resource" path.
+func (s *Server) serveResources(w http.ResponseWriter, r *http.Request, parts []string) {
+	if len(parts) == 0 {
+		writeMethodNotAllowed(w, r)
+		return
+	}
+
+	rt, ok := s.resourceTypes[parts[0]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "resource type %s does not exist", parts[0])
+		return
+	}
+
+	if len(parts) == 1 {
+		switch r.Method {
+		case "GET":
+			s.listResources(w, r, rt)
+		case "POST":
+			s.createResource(w, r, rt)
+		default:
+			writeError(w, http.StatusNotImplemented, "the %s method isn't supported by the fake Gnocchi server", r.Method)
+		}
+		return
+	}
+
+	res, ok := s.resources[parts[1]]
+	if !ok || (rt.Name != genericResourceType && rt.Name != res.Type) {
+		writeError(w, http.StatusNotFound, "resource %s does not exist", parts[1])
+		return
+	}
+
+	switch {
+	case len(parts) == 2 && r.Method == "GET":
+		writeJSON(w, http.StatusOK, res.toMap())
+	case len(parts) == 2 && r.Method == "PATCH":
+		s.updateResource(w, r, res)
+	case len(parts) == 2 && r.Method == "DELETE":
+		for _, metricID := range res.Metrics {
+			delete(s.metrics, metricID)
+		}
+		delete(s.resources, res.ID)
+		writeNoContent(w)
+	case len(parts) == 3 && parts[2] == "history" && r.Method == "GET":
+		history := append([]map[string]interface{}{}, res.revisions...)
+		writeJSON(w, http.StatusOK, append(history, res.toMap()))
+	case len(parts) == 3 && parts[2] == "metric" && r.Method == "GET":
+		names := make([]string, 0, len(res.Metrics))
+		for name := range res.Metrics {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		result := make([]map[string]interface{}, len(names))
+		for i, name := range names {
+			result[i] = s.metricMap(s.metrics[res.Metrics[name]], false)
+		}
+		writeJSON(w, http.StatusOK, result)
+	case len(parts) == 3 && parts[2] == "metric" && r.Method == "POST":
+		s.addResourceMetrics(w, r, res)
+	case len(parts) >= 4 && parts[2] == "metric":
+		metricID, ok := res.Metrics[parts[3]]
+		if !ok {
+			writeError(w, http.StatusNotFound, "metric %s of the resource %s does not exist", parts[3], res.ID)
+			return
+		}
+
+		switch {
+		case len(parts) == 4 && r.Method == "DELETE":
+			delete(res.Metrics, parts[3])
+			delete(s.metrics, metricID)
+			writeNoContent(w)
+		case len(parts) == 5 && parts[4] == "measures":
+			s.serveMeasures(w, r, s.metrics[metricID])
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+// listResources handles resource list requests. Resources of all types are
+// listed for the "generic" resource type.
+func (s *Server) listResources(w http.ResponseWriter, r *http.Request, rt *resourceType) {
+	ids := make([]string, 0, len(s.resources))
+	for id, res := range s.resources {
+		if rt.Name == genericResourceType || res.Type == rt.Name {
+			ids = append(ids, id)
+		}
+	}
+
+	ids, err := paginate(r, ids)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "%s", err)
+		return
+	}
+
+	result := make([]map[string]interface{}, len(ids))
+	for i, id := range ids {
+		result[i] = s.resources[id].toMap()
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// createResource handles resource create requests.
+func (s *Server) createResource(w http.ResponseWriter, r *http.Request, rt *resourceType) {
+	var body map[string]interface{}
+	if err := decodeBody(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid resource: %s", err)
+		return
+	}
+
+	id, _ := body["id"].(string)
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "missing resource id")
+		return
+	}
+	if _, ok := s.resources[id]; ok {
+		writeError(w, http.StatusConflict, "resource %s already exists", id)
+		return
+	}
+
+	now := time.Now().UTC()
+	res := &resource{
+		ID:                 id,
+		Type:               rt.Name,
+		OriginalResourceID: id,
+		StartedAt:          now,
+		RevisionStart:      now,
+		Metrics:            make(map[string]string),
+		ExtraAttributes:    make(map[string]interface{}),
+	}
+
+	metrics, err := s.applyResourceBody(res, rt, body, true)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid resource: %s", err)
+		return
+	}
+
+	s.resources[res.ID] = res
+	s.linkResourceMetrics(res, metrics)
+
+	writeJSON(w, http.StatusCreated, res.toMap())
+}
+
+// updateResource handles resource update requests and saves the previous
+// revision of the resource.
+func (s *Server) updateResource(w http.ResponseWriter, r *http.Request, res *resource) {
+	var body map[string]interface{}
+	if err := decodeBody(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid resource: %s", err)
+		return
+	}
+
+	// Apply changes to a copy to leave the resource intact on errors.
+	updated := *res
+	updated.ExtraAttributes = make(map[string]interface{}, len(res.ExtraAttributes))
+	for k, v := range res.ExtraAttributes {
+		updated.ExtraAttributes[k] = v
+	}
+	metrics, err := s.applyResourceBody(&updated, s.resourceTypes[res.Type], body, false)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid resource: %s", err)
+		return
+	}
+
+	now := time.Now().UTC()
+	revision := res.toMap()
+	revision["revision_end"] = formatTimestamp(now)
+	updated.revisions = append(updated.revisions, revision)
+	updated.RevisionStart = now
+
+	*res = updated
+	if metrics != nil {
+		for _, metricID := range res.Metrics {
+			if m, ok := s.metrics[metricID]; ok {
+				m.ResourceID = ""
+				m.Name = ""
+			}
+		}
+		res.Metrics = make(map[string]string)
+		s.linkResourceMetrics(res, metrics)
+	}
+
+	writeJSON(w, http.StatusOK, res.toMap())
+}
+
+// applyResourceBody populates resource fields from the request body and
+// validates its extra attributes. It returns metrics that need to be linked
+// to the resource.
+func (s *Server) applyResourceBody(res *resource, rt *resourceType, body map[string]interface{}, create bool) (map[string]*metric, error) {
+	var err error
+	var metrics map[string]*metric
+
+	extraAttributes := make(map[string]interface{})
+	for k, v := range body {
+		switch k {
+		case "id", "original_resource_id":
+		case "project_id", "user_id":
+			value, ok := v.(string)
+			if !ok && v != nil {
+				return nil, fmt.Errorf("%s should be a string", k)
+			}
+			if k == "project_id" {
+				res.ProjectID = value
+			} else {
+				res.UserID = value
+			}
+		case "started_at":
+			if res.StartedAt, err = parseTimestamp(v); err != nil {
+				return nil, err
+			}
+		case "ended_at":
+			res.EndedAt = nil
+			if v != nil {
+				endedAt, err := parseTimestamp(v)
+				if err != nil {
+					return nil, err
+				}
+				res.EndedAt = &endedAt
+			}
+		case "metrics":
+			value, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("metrics should be a map")
+			}
+			if metrics, err = s.resourceMetrics(res, value); err != nil {
+				return nil, err
+			}
+		default:
+			if isResourceField(k) {
+				return nil, fmt.Errorf("%s can't be set", k)
+			}
+			extraAttributes[k] = v
+		}
+	}
+
+	schema, err := rt.schema()
+	if err != nil {
+		return nil, err
+	}
+	if create {
+		err = resources.CreateOpts{ExtraAttributes: extraAttributes}.Validate(schema)
+	} else {
+		err = resources.UpdateOpts{ExtraAttributes: extraAttributes}.Validate(schema)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range extraAttributes {
+		res.ExtraAttributes[k] = v
+	}
+	if create {
+		// Gnocchi sets all attributes of a new resource.
+		for name := range rt.Attributes {
+			if _, ok := res.ExtraAttributes[name]; !ok {
+				res.ExtraAttributes[name] = nil
+			}
+		}
+	}
+
+	return metrics, nil
+}
+
+// isResourceField checks that the key is one of the attributes of every resource.
+func isResourceField(key string) bool {
+	for _, field := range resourceFields {
+		if key == field {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceMetrics resolves metrics from the "metrics" field of resource
+// requests. Values are IDs of existing metrics or options of new metrics.
+func (s *Server) resourceMetrics(res *resource, values map[string]interface{}) (map[string]*metric, error) {
+	metrics := make(map[string]*metric, len(values))
+	for name, v := range values {
+		switch value := v.(type) {
+		case string:
+			m, ok := s.metrics[value]
+			if !ok {
+				return nil, fmt.Errorf("metric %s does not exist", value)
+			}
+			if m.ResourceID != "" && m.ResourceID != res.ID {
+				return nil, fmt.Errorf("metric %s is already attached to the resource %s", value, m.ResourceID)
+			}
+			metrics[name] = m
+		case map[string]interface{}:
+			archivePolicyName, _ := value["archive_policy_name"].(string)
+			unit, _ := value["unit"].(string)
+			m, err := s.newMetric(name, archivePolicyName, unit)
+			if err != nil {
+				return nil, err
+			}
+			metrics[name] = m
+		default:
+			return nil, fmt.Errorf("invalid metric %s", name)
+		}
+	}
+
+	return metrics, nil
+}
+
+// linkResourceMetrics stores metrics and attaches them to the resource.
+func (s *Server) linkResourceMetrics(res *resource, metrics map[string]*metric) {
+	for name, m := range metrics {
+		m.Name = name
+		m.ResourceID = res.ID
+		s.metrics[m.ID] = m
+		res.Metrics[name] = m.ID
+	}
+}
+
+// addResourceMetrics handles requests to add metrics to the resource.
+func (s *Server) addResourceMetrics(w http.ResponseWriter, r *http.Request, res *resource) {
+	var body map[string]interface{}
+	if err := decodeBody(r, &body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid metrics: %s", err)
+		return
+	}
+
+	for name := range body {
+		if _, ok := res.Metrics[name]; ok {
+			writeError(w, http.StatusConflict, "metric %s of the resource %s already exists", name, res.ID)
+			return
+		}
+	}
+
+	metrics, err := s.resourceMetrics(res, body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid metrics: %s", err)
+		return
+	}
+	s.linkResourceMetrics(res, metrics)
+
+	names := make([]string, 0, len(res.Metrics))
+	for name := range res.Metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]map[string]interface{}, len(names))
+	for i, name := range names {
+		result[i] = s.metricMap(s.metrics[res.Metrics[name]], false)
+	}
+	writeJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,242 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gophercloud/utils/gnocchi/metric/v1/resourcetypes"
+)
+
+// genericResourceType is a name of the resource type that every Gnocchi
+// installation has. All other resource types inherit it.
+const genericResourceType = "generic"
+
+// resourceType represents a stored Gnocchi resource type.
+type resourceType struct {
+	Name       string                            `json:"name"`
+	State      string                            `json:"state"`
+	Attributes map[string]map[string]interface{} `json:"attributes"`
+}
+
+// schema converts the stored resource type into the resourcetypes.ResourceType
+// to validate resource attributes.
+func (rt *resourceType) schema() (resourcetypes.ResourceType, error) {
+	var s resourcetypes.ResourceType
+	b, err := json.Marshal(rt)
+	if err != nil {
+		return s, err
+	}
+	err = json.Unmarshal(b, &s)
+	return s, err
+}
+
+// normalizeAttribute validates a definition of the resource type attribute
+// and sets default values of its details. It also returns a fill value from
+// the attribute options.
+func normalizeAttribute(definition map[string]interface{}) (map[string]interface{}, interface{}, error) {
+	attribute := make(map[string]interface{}, len(definition))
+	for k, v := range definition {
+		attribute[k] = v
+	}
+
+	var fill interface{}
+	if options, ok := attribute["options"].(map[string]interface{}); ok {
+		fill = options["fill"]
+	}
+	delete(attribute, "options")
+
+	if _, ok := attribute["required"]; !ok {
+		attribute["required"] = true
+	}
+	if _, ok := attribute["required"].(bool); !ok {
+		return nil, nil, fmt.Errorf("attribute detail 'required' should be a bool")
+	}
+
+	switch attribute["type"] {
+	case resourcetypes.AttributeTypeString:
+		if _, ok := attribute["min_length"]; !ok {
+			attribute["min_length"] = 0
+		}
+		if _, ok := attribute["max_length"]; !ok {
+			attribute["max_length"] = 255
+		}
+	case resourcetypes.AttributeTypeNumber:
+		if _, ok := attribute["min"]; !ok {
+			attribute["min"] = nil
+		}
+		if _, ok := attribute["max"]; !ok {
+			attribute["max"] = nil
+		}
+	case resourcetypes.AttributeTypeUUID, resourcetypes.AttributeTypeBool, resourcetypes.AttributeTypeDatetime:
+	default:
+		return nil, nil, fmt.Errorf("unknown attribute type: %v", attribute["type"])
+	}
+
+	return attribute, fill, nil
+}
+
+// serveResourceTypes handles requests to the "/v1/resource_type" path.
+func (s *Server) serveResourceTypes(w http.ResponseWriter, r *http.Request, parts []string) {
+	switch {
+	case len(parts) == 0 && r.Method == "GET":
+		names := make([]string, 0, len(s.resourceTypes))
+		for name := range s.resourceTypes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		result := make([]*resourceType, len(names))
+		for i, name := range names {
+			result[i] = s.resourceTypes[name]
+		}
+		writeJSON(w, http.StatusOK, result)
+	case len(parts) == 0 && r.Method == "POST":
+		s.createResourceType(w, r)
+	case len(parts) == 1:
+		rt, ok := s.resourceTypes[parts[0]]
+		if !ok {
+			writeError(w, http.StatusNotFound, "resource type %s does not exist", parts[0])
+			return
+		}
+
+		switch r.Method {
+		case "GET":
+			writeJSON(w, http.StatusOK, rt)
+		case "PATCH":
+			s.updateResourceType(w, r, rt)
+		case "DELETE":
+			if rt.Name == genericResourceType {
+				writeError(w, http.StatusBadRequest, "resource type %s can't be deleted", rt.Name)
+				return
+			}
+			for _, res := range s.resources {
+				if res.Type == rt.Name {
+					writeError(w, http.StatusBadRequest, "resource type %s is still in use", rt.Name)
+					return
+				}
+			}
+			delete(s.resourceTypes, rt.Name)
+			writeNoContent(w)
+		default:
+			writeMethodNotAllowed(w, r)
+		}
+	default:
+		writeMethodNotAllowed(w, r)
+	}
+}
+
+// createResourceType handles resource type create requests.
+func (s *Server) createResourceType(w http.ResponseWriter, r *http.Request) {
+	var opts struct {
+		Name       string                            `json:"name"`
+		Attributes map[string]map[string]interface{} `json:"attributes"`
+	}
+	if err := decodeBody(r, &opts); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid resource type: %s", err)
+		return
+	}
+	if opts.Name == "" {
+		writeError(w, http.StatusBadRequest, "missing resource type name")
+		return
+	}
+	if _, ok := s.resourceTypes[opts.Name]; ok {
+		writeError(w, http.StatusConflict, "resource type %s already exists", opts.Name)
+		return
+	}
+
+	rt := &resourceType{
+		Name:       opts.Name,
+		State:      "active",
+		Attributes: make(map[string]map[string]interface{}, len(opts.Attributes)),
+	}
+	for name, definition := range opts.Attributes {
+		attribute, _, err := normalizeAttribute(definition)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid attribute %s: %s", name, err)
+			return
+		}
+		rt.Attributes[name] = attribute
+	}
+	s.resourceTypes[rt.Name] = rt
+
+	writeJSON(w, http.StatusCreated, rt)
+}
+
+// updateResourceType handles JSON patch requests that add or remove
+// attributes of the resource type.
+func (s *Server) updateResourceType(w http.ResponseWriter, r *http.Request, rt *resourceType) {
+	var operations []struct {
+		Op    string                 `json:"op"`
+		Path  string                 `json:"path"`
+		Value map[string]interface{} `json:"value"`
+	}
+	if err := decodeBody(r, &operations); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid resource type patch: %s", err)
+		return
+	}
+
+	// Changes are performed on a copy to leave the resource type intact
+	// if one of operations is invalid.
+	attributes := make(map[string]map[string]interface{}, len(rt.Attributes))
+	for k, v := range rt.Attributes {
+		attributes[k] = v
+	}
+	fills := make(map[string]interface{})
+	var removed []string
+
+	for _, operation := range operations {
+		if !strings.HasPrefix(operation.Path, resourcetypes.AttributeCommonPath+"/") {
+			writeError(w, http.StatusBadRequest, "invalid path: %s", operation.Path)
+			return
+		}
+		name := strings.TrimPrefix(operation.Path, resourcetypes.AttributeCommonPath+"/")
+
+		switch resourcetypes.AttributeOperation(operation.Op) {
+		case resourcetypes.AttributeAdd:
+			if _, ok := attributes[name]; ok {
+				writeError(w, http.StatusBadRequest, "attribute %s already exists", name)
+				return
+			}
+			attribute, fill, err := normalizeAttribute(operation.Value)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "invalid attribute %s: %s", name, err)
+				return
+			}
+			if attribute["required"] == true && fill == nil {
+				writeError(w, http.StatusBadRequest, "missing a fill value of the required attribute %s", name)
+				return
+			}
+			attributes[name] = attribute
+			fills[name] = fill
+		case resourcetypes.AttributeRemove:
+			if _, ok := attributes[name]; !ok {
+				writeError(w, http.StatusBadRequest, "attribute %s does not exist", name)
+				return
+			}
+			delete(attributes, name)
+			delete(fills, name)
+			removed = append(removed, name)
+		default:
+			writeError(w, http.StatusBadRequest, "unsupported operation: %s", operation.Op)
+			return
+		}
+	}
+
+	rt.Attributes = attributes
+	for _, res := range s.resources {
+		if res.Type != rt.Name {
+			continue
+		}
+		for _, name := range removed {
+			delete(res.ExtraAttributes, name)
+		}
+		for name, fill := range fills {
+			res.ExtraAttributes[name] = fill
+		}
+	}
+
+	writeJSON(w, http.StatusOK, rt)
+}
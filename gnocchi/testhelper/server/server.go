@@ -0,0 +1,229 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/testhelper/client"
+	"github.com/gophercloud/utils/gnocchi"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/archivepolicies"
+)
+
+const (
+	// TokenID is a fake Identity service token used by the ServiceClient.
+	TokenID = client.TokenID
+
+	// UserID is a fake Identity user that creates all entities.
+	UserID = "fdcfb420c09645e69e177a0bb1950884"
+
+	// ProjectID is a fake Identity project that creates all entities.
+	ProjectID = "3d40ca37723449118987b9f288f4ae84"
+)
+
+// Server is a stateful in-memory fake of the Gnocchi v1 API.
+// It's safe to use a Server from multiple goroutines.
+type Server struct {
+	// URL is a base URL of the Server without the API version.
+	URL string
+
+	server *httptest.Server
+
+	mu              sync.Mutex
+	archivePolicies map[string]*archivepolicies.ArchivePolicy
+	resourceTypes   map[string]*resourceType
+	resources       map[string]*resource
+	metrics         map[string]*metric
+}
+
+// New starts a new Server with the "generic" resource type and "low",
+// "medium" and "high" archive policies that Gnocchi creates by default.
+// Call its Close method to stop the Server.
+func New() *Server {
+	s := &Server{
+		archivePolicies: make(map[string]*archivepolicies.ArchivePolicy),
+		resourceTypes: map[string]*resourceType{
+			genericResourceType: {
+				Name:       genericResourceType,
+				State:      "active",
+				Attributes: make(map[string]map[string]interface{}),
+			},
+		},
+		resources: make(map[string]*resource),
+		metrics:   make(map[string]*metric),
+	}
+
+	for _, ap := range defaultArchivePolicies() {
+		ap := ap
+		s.archivePolicies[ap.Name] = &ap
+	}
+
+	s.server = httptest.NewServer(s)
+	s.URL = s.server.URL
+
+	return s
+}
+
+// Close shuts down the Server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// ServiceClient returns a Gnocchi v1 service client that sends requests to the Server.
+func (s *Server) ServiceClient() *gophercloud.ServiceClient {
+	return &gophercloud.ServiceClient{
+		ProviderClient: &gophercloud.ProviderClient{TokenID: TokenID},
+		Endpoint:       s.URL + "/",
+		ResourceBase:   s.URL + "/v1/",
+		Type:           "metric",
+	}
+}
+
+// ServeHTTP routes requests of the Gnocchi v1 API.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Auth-Token") == "" {
+		writeError(w, http.StatusUnauthorized, "missing the X-Auth-Token header")
+		return
+	}
+
+	path := strings.Trim(r.URL.Path, "/")
+	if path != "v1" && !strings.HasPrefix(path, "v1/") {
+		writeError(w, http.StatusNotFound, "unknown API version of the %s path", r.URL.Path)
+		return
+	}
+	parts := strings.Split(strings.TrimPrefix(strings.TrimPrefix(path, "v1"), "/"), "/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch parts[0] {
+	case "archive_policy":
+		s.serveArchivePolicies(w, r, parts[1:])
+	case "resource_type":
+		s.serveResourceTypes(w, r, parts[1:])
+	case "resource":
+		s.serveResources(w, r, parts[1:])
+	case "metric":
+		s.serveMetrics(w, r, parts[1:])
+	case "batch":
+		s.serveBatch(w, r, parts[1:])
+	default:
+		writeError(w, http.StatusNotImplemented, "the %s path isn't supported by the fake Gnocchi server", r.URL.Path)
+	}
+}
+
+// writeJSON writes the value as a JSON response body.
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error in the same format as Gnocchi API.
+func writeError(w http.ResponseWriter, code int, format string, args ...interface{}) {
+	writeJSON(w, code, map[string]interface{}{
+		"code":        code,
+		"title":       http.StatusText(code),
+		"description": fmt.Sprintf(format, args...),
+	})
+}
+
+// writeAccepted writes a response to requests that are processed asynchronously
+// by Gnocchi.
+func writeAccepted(w http.ResponseWriter) {
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{})
+}
+
+// writeNoContent writes an empty response.
+func writeNoContent(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeMethodNotAllowed writes an error for unsupported methods of the path.
+func writeMethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	writeError(w, http.StatusMethodNotAllowed, "the %s method isn't allowed for the %s path", r.Method, r.URL.Path)
+}
+
+// decodeBody decodes a JSON request body.
+func decodeBody(r *http.Request, v interface{}) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// paginate applies the "limit" and "marker" query parameters to sorted IDs.
+func paginate(r *http.Request, ids []string) ([]string, error) {
+	sort.Strings(ids)
+
+	query := r.URL.Query()
+	if marker := query.Get("marker"); marker != "" {
+		i := sort.SearchStrings(ids, marker)
+		if i == len(ids) || ids[i] != marker {
+			return nil, fmt.Errorf("invalid marker: %q", marker)
+		}
+		ids = ids[i+1:]
+	}
+
+	if v := query.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			return nil, fmt.Errorf("invalid limit: %q", v)
+		}
+		if limit < len(ids) {
+			ids = ids[:limit]
+		}
+	}
+
+	return ids, nil
+}
+
+// newUUID generates a random UUID.
+func newUUID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// parseTimestamp parses timestamps in formats accepted by Gnocchi.
+func parseTimestamp(v interface{}) (time.Time, error) {
+	switch value := v.(type) {
+	case string:
+		for _, format := range []string{time.RFC3339Nano, gnocchi.RFC3339NanoTimezone, gnocchi.RFC3339NanoNoTimezone} {
+			if t, err := time.Parse(format, value); err == nil {
+				return t.UTC(), nil
+			}
+		}
+		if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+			return time.Unix(0, int64(seconds*float64(time.Second))).UTC(), nil
+		}
+	case json.Number:
+		if seconds, err := value.Float64(); err == nil {
+			return time.Unix(0, int64(seconds*float64(time.Second))).UTC(), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid timestamp: %v", v)
+}
+
+// formatTimestamp formats timestamps the same way as Gnocchi responses.
+func formatTimestamp(t time.Time) string {
+	return t.UTC().Format(gnocchi.RFC3339NanoTimezone)
+}
+
+// formatOptionalTimestamp formats timestamps that can be omitted.
+func formatOptionalTimestamp(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return formatTimestamp(*t)
+}
@@ -0,0 +1,2 @@
+// server unit tests
+package testing
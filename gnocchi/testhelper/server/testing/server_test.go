@@ -0,0 +1,416 @@
+package testing
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/utils/gnocchi"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/archivepolicies"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/measures"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/metrics"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/resources"
+	"github.com/gophercloud/utils/gnocchi/metric/v1/resourcetypes"
+	"github.com/gophercloud/utils/gnocchi/testhelper/server"
+)
+
+func TestUnauthorized(t *testing.T) {
+	srv := server.New()
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/v1/archive_policy")
+	th.AssertNoErr(t, err)
+	defer resp.Body.Close()
+	th.AssertEquals(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestArchivePolicies(t *testing.T) {
+	srv := server.New()
+	defer srv.Close()
+	client := srv.ServiceClient()
+
+	allPages, err := archivepolicies.List(client).AllPages()
+	th.AssertNoErr(t, err)
+	allArchivePolicies, err := archivepolicies.ExtractArchivePolicies(allPages)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 3, len(allArchivePolicies))
+	th.AssertEquals(t, "high", allArchivePolicies[0].Name)
+	th.AssertEquals(t, "low", allArchivePolicies[1].Name)
+	th.AssertEquals(t, "medium", allArchivePolicies[2].Name)
+
+	createOpts := archivepolicies.CreateOpts{
+		Name:               "test_policy",
+		AggregationMethods: []string{"mean", "max"},
+		Definition: []archivepolicies.ArchivePolicyDefinitionOpts{
+			{
+				Granularity: gnocchi.Duration(time.Minute),
+				TimeSpan:    gnocchi.Duration(time.Hour),
+			},
+		},
+	}
+	ap, err := archivepolicies.Create(client, createOpts).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 60, ap.Definition[0].Points)
+
+	_, err = archivepolicies.Create(client, createOpts).Extract()
+	if _, ok := err.(gophercloud.ErrUnexpectedResponseCode); !ok {
+		t.Fatalf("expected a conflict error, got %v", err)
+	}
+
+	ap, err = archivepolicies.Update(client, "test_policy", archivepolicies.UpdateOpts{
+		Definition: []archivepolicies.ArchivePolicyDefinitionOpts{
+			{
+				Granularity: gnocchi.Duration(time.Minute),
+				TimeSpan:    gnocchi.Duration(2 * time.Hour),
+			},
+		},
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 120, ap.Definition[0].Points)
+
+	_, err = archivepolicies.Update(client, "test_policy", archivepolicies.UpdateOpts{
+		Definition: []archivepolicies.ArchivePolicyDefinitionOpts{
+			{
+				Granularity: gnocchi.Duration(time.Second),
+				TimeSpan:    gnocchi.Duration(time.Hour),
+			},
+		},
+	}).Extract()
+	if _, ok := err.(gophercloud.ErrDefault400); !ok {
+		t.Fatalf("expected a bad request error, got %v", err)
+	}
+
+	metric, err := metrics.Create(client, metrics.CreateOpts{ArchivePolicyName: "test_policy"}).Extract()
+	th.AssertNoErr(t, err)
+
+	err = archivepolicies.Delete(client, "test_policy").ExtractErr()
+	if _, ok := err.(gophercloud.ErrDefault400); !ok {
+		t.Fatalf("expected a bad request error, got %v", err)
+	}
+
+	th.AssertNoErr(t, metrics.Delete(client, metric.ID).ExtractErr())
+	th.AssertNoErr(t, archivepolicies.Delete(client, "test_policy").ExtractErr())
+
+	_, err = archivepolicies.Get(client, "test_policy").Extract()
+	if _, ok := err.(gophercloud.ErrDefault404); !ok {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestResources(t *testing.T) {
+	srv := server.New()
+	defer srv.Close()
+	client := srv.ServiceClient()
+
+	displayName, err := resourcetypes.StringAttribute(true, gophercloud.IntToPointer(1), gophercloud.IntToPointer(255))
+	th.AssertNoErr(t, err)
+
+	_, err = resourcetypes.Create(client, resourcetypes.CreateOpts{
+		Name: "compute_instance",
+		Attributes: map[string]resourcetypes.AttributeOpts{
+			"display_name": displayName,
+			"image_ref":    resourcetypes.UUIDAttribute(false),
+		},
+	}).Extract()
+	th.AssertNoErr(t, err)
+
+	_, err = resources.Create(client, "compute_instance", resources.CreateOpts{
+		ID: "23d5d3f7-9dfa-4f73-b72b-8b0b0063ec55",
+	}).Extract()
+	if _, ok := err.(gophercloud.ErrDefault400); !ok {
+		t.Fatalf("expected a bad request error, got %v", err)
+	}
+
+	resource, err := resources.Create(client, "compute_instance", resources.CreateOpts{
+		ID:        "23d5d3f7-9dfa-4f73-b72b-8b0b0063ec55",
+		ProjectID: "4154f08883334e0494c41155c33c0fc9",
+		Metrics: map[string]interface{}{
+			"cpu.delta": map[string]string{
+				"archive_policy_name": "medium",
+			},
+		},
+		ExtraAttributes: map[string]interface{}{
+			"display_name": "MyInstance00",
+		},
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "compute_instance", resource.Type)
+	th.AssertEquals(t, "4154f08883334e0494c41155c33c0fc9", resource.ProjectID)
+	th.AssertEquals(t, server.UserID+":"+server.ProjectID, resource.Creator)
+	th.AssertEquals(t, "MyInstance00", resource.ExtraAttributes["display_name"])
+	th.AssertEquals(t, 1, len(resource.Metrics))
+
+	metric, err := metrics.Get(client, resource.Metrics["cpu.delta"]).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "cpu.delta", metric.Name)
+	th.AssertEquals(t, resource.ID, metric.ResourceID)
+	th.AssertEquals(t, resource.ID, metric.Resource.ID)
+	th.AssertEquals(t, "medium", metric.ArchivePolicy.Name)
+
+	resource, err = resources.Update(client, "compute_instance", resource.ID, resources.UpdateOpts{
+		ExtraAttributes: map[string]interface{}{
+			"display_name": "MyInstance01",
+		},
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "MyInstance01", resource.ExtraAttributes["display_name"])
+
+	allPages, err := resources.History(client, "compute_instance", resource.ID, nil).AllPages()
+	th.AssertNoErr(t, err)
+	revisions, err := resources.ExtractResources(allPages)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2, len(revisions))
+	th.AssertEquals(t, "MyInstance00", revisions[0].ExtraAttributes["display_name"])
+	th.AssertEquals(t, revisions[1].RevisionStart, revisions[0].RevisionEnd)
+
+	allPages, err = resources.List(client, resources.ListOpts{}, "generic").AllPages()
+	th.AssertNoErr(t, err)
+	allResources, err := resources.ExtractResources(allPages)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(allResources))
+
+	addedMetrics, err := resources.AddMetrics(client, "compute_instance", resource.ID, resources.AddMetricsOpts{
+		Metrics: map[string]interface{}{
+			"memory": map[string]string{
+				"archive_policy_name": "low",
+				"unit":                "MB",
+			},
+		},
+	}).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2, len(addedMetrics))
+	th.AssertEquals(t, "memory", addedMetrics[1].Name)
+	th.AssertEquals(t, "MB", addedMetrics[1].Unit)
+
+	th.AssertNoErr(t, resources.RemoveMetric(client, "compute_instance", resource.ID, "memory").ExtractErr())
+
+	allPages, err = resources.ListMetrics(client, "compute_instance", resource.ID).AllPages()
+	th.AssertNoErr(t, err)
+	resourceMetrics, err := resources.ExtractMetrics(allPages)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(resourceMetrics))
+	th.AssertEquals(t, "cpu.delta", resourceMetrics[0].Name)
+
+	enabled := resourcetypes.BoolAttribute(true).WithFill(true)
+	_, err = resourcetypes.Update(client, "compute_instance", resourcetypes.UpdateOpts{
+		Attributes: []resourcetypes.AttributeUpdateOpts{
+			{
+				Name:      "enabled",
+				Operation: resourcetypes.AttributeAdd,
+				Value:     &enabled,
+			},
+		},
+	}).Extract()
+	th.AssertNoErr(t, err)
+
+	resource, err = resources.Get(client, "compute_instance", resource.ID).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, resource.ExtraAttributes["enabled"])
+
+	err = resourcetypes.Delete(client, "compute_instance").ExtractErr()
+	if _, ok := err.(gophercloud.ErrDefault400); !ok {
+		t.Fatalf("expected a bad request error, got %v", err)
+	}
+
+	th.AssertNoErr(t, resources.Delete(client, "compute_instance", resource.ID).ExtractErr())
+	_, err = metrics.Get(client, metric.ID).Extract()
+	if _, ok := err.(gophercloud.ErrDefault404); !ok {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+
+	th.AssertNoErr(t, resourcetypes.Delete(client, "compute_instance").ExtractErr())
+}
+
+func TestMeasures(t *testing.T) {
+	srv := server.New()
+	defer srv.Close()
+	client := srv.ServiceClient()
+
+	_, err := archivepolicies.Create(client, archivepolicies.CreateOpts{
+		Name:               "test_policy",
+		AggregationMethods: []string{"mean", "max", "count", "95pct", "rate:sum"},
+		Definition: []archivepolicies.ArchivePolicyDefinitionOpts{
+			{
+				Granularity: gnocchi.Duration(time.Minute),
+				Points:      gophercloud.IntToPointer(3),
+			},
+			{
+				Granularity: gnocchi.Duration(time.Hour),
+				Points:      gophercloud.IntToPointer(24),
+			},
+		},
+	}).Extract()
+	th.AssertNoErr(t, err)
+
+	metric, err := metrics.Create(client, metrics.CreateOpts{ArchivePolicyName: "test_policy"}).Extract()
+	th.AssertNoErr(t, err)
+
+	var measureOpts []measures.MeasureOpts
+	start := time.Date(2018, 1, 10, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 8; i++ {
+		timestamp := start.Add(time.Duration(i) * 30 * time.Second)
+		measureOpts = append(measureOpts, measures.MeasureOpts{
+			Timestamp: &timestamp,
+			Value:     float64(i),
+		})
+	}
+	err = measures.Create(client, metric.ID, measures.CreateOpts{Measures: measureOpts}).ExtractErr()
+	th.AssertNoErr(t, err)
+
+	allPages, err := measures.List(client, metric.ID, measures.ListOpts{}).AllPages()
+	th.AssertNoErr(t, err)
+	allMeasures, err := measures.ExtractMeasures(allPages)
+	th.AssertNoErr(t, err)
+
+	// The minutely aggregates keep only the last 3 points.
+	expected := []measures.Measure{
+		{Timestamp: start, Granularity: 3600, Value: 3.5},
+		{Timestamp: start.Add(time.Minute), Granularity: 60, Value: 2.5},
+		{Timestamp: start.Add(2 * time.Minute), Granularity: 60, Value: 4.5},
+		{Timestamp: start.Add(3 * time.Minute), Granularity: 60, Value: 6.5},
+	}
+	th.AssertDeepEquals(t, expected, allMeasures)
+
+	stop := start.Add(3 * time.Minute)
+	allPages, err = measures.List(client, metric.ID, measures.ListOpts{
+		Aggregation: "max",
+		Granularity: gnocchi.Duration(time.Minute),
+		Resample:    gnocchi.Duration(2 * time.Minute),
+		Stop:        &stop,
+	}).AllPages()
+	th.AssertNoErr(t, err)
+	allMeasures, err = measures.ExtractMeasures(allPages)
+	th.AssertNoErr(t, err)
+
+	expected = []measures.Measure{
+		{Timestamp: start, Granularity: 120, Value: 3},
+		{Timestamp: start.Add(2 * time.Minute), Granularity: 120, Value: 5},
+	}
+	th.AssertDeepEquals(t, expected, allMeasures)
+
+	allPages, err = measures.List(client, metric.ID, measures.ListOpts{
+		Aggregation: "95pct",
+		Granularity: gnocchi.Duration(time.Hour),
+	}).AllPages()
+	th.AssertNoErr(t, err)
+	allMeasures, err = measures.ExtractMeasures(allPages)
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, 1, len(allMeasures))
+	th.AssertEquals(t, start, allMeasures[0].Timestamp)
+	th.AssertEquals(t, true, allMeasures[0].Value > 6.649 && allMeasures[0].Value < 6.651)
+
+	// Differences between consecutive measures are all equal to 1.
+	allPages, err = measures.List(client, metric.ID, measures.ListOpts{
+		Aggregation: "rate:sum",
+		Granularity: gnocchi.Duration(time.Hour),
+	}).AllPages()
+	th.AssertNoErr(t, err)
+	allMeasures, err = measures.ExtractMeasures(allPages)
+	th.AssertNoErr(t, err)
+
+	expected = []measures.Measure{
+		{Timestamp: start, Granularity: 3600, Value: 7},
+	}
+	th.AssertDeepEquals(t, expected, allMeasures)
+
+	_, err = measures.List(client, metric.ID, measures.ListOpts{Aggregation: "min"}).AllPages()
+	if _, ok := err.(gophercloud.ErrDefault404); !ok {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+
+	_, err = measures.List(client, metric.ID, measures.ListOpts{
+		Granularity: gnocchi.Duration(time.Second),
+	}).AllPages()
+	if _, ok := err.(gophercloud.ErrDefault404); !ok {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestBatchMeasures(t *testing.T) {
+	srv := server.New()
+	defer srv.Close()
+	client := srv.ServiceClient()
+
+	metric, err := metrics.Create(client, metrics.CreateOpts{ArchivePolicyName: "low"}).Extract()
+	th.AssertNoErr(t, err)
+
+	resource, err := resources.Create(client, "generic", resources.CreateOpts{
+		ID: "75274f99-faf6-4112-a6d5-2794cb07c789",
+	}).Extract()
+	th.AssertNoErr(t, err)
+
+	timestamp := time.Date(2018, 1, 10, 12, 0, 0, 0, time.UTC)
+	measureOpts := []measures.MeasureOpts{
+		{
+			Timestamp: &timestamp,
+			Value:     42,
+		},
+	}
+
+	err = measures.BatchCreateMetrics(client, measures.BatchCreateMetricsOpts{
+		{
+			ID:       "unknown",
+			Measures: measureOpts,
+		},
+	}).ExtractErr()
+	if _, ok := err.(gophercloud.ErrDefault400); !ok {
+		t.Fatalf("expected a bad request error, got %v", err)
+	}
+
+	err = measures.BatchCreateMetrics(client, measures.BatchCreateMetricsOpts{
+		{
+			ID:       metric.ID,
+			Measures: measureOpts,
+		},
+	}).ExtractErr()
+	th.AssertNoErr(t, err)
+
+	batchResourcesMetricsOpts := measures.BatchCreateResourcesMetricsOpts{
+		BatchResourcesMetrics: []measures.BatchResourcesMetricsOpts{
+			{
+				ResourceID: resource.ID,
+				ResourcesMetrics: []measures.ResourcesMetricsOpts{
+					{
+						MetricName:        "network.incoming.packets.rate",
+						ArchivePolicyName: "high",
+						Unit:              "packet/s",
+						Measures:          measureOpts,
+					},
+				},
+			},
+		},
+	}
+	err = measures.BatchCreateResourcesMetrics(client, batchResourcesMetricsOpts).ExtractErr()
+	if _, ok := err.(gophercloud.ErrDefault400); !ok {
+		t.Fatalf("expected a bad request error, got %v", err)
+	}
+
+	batchResourcesMetricsOpts.CreateMetrics = true
+	err = measures.BatchCreateResourcesMetrics(client, batchResourcesMetricsOpts).ExtractErr()
+	th.AssertNoErr(t, err)
+
+	allPages, err := measures.List(client, metric.ID, measures.ListOpts{}).AllPages()
+	th.AssertNoErr(t, err)
+	allMeasures, err := measures.ExtractMeasures(allPages)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(allMeasures))
+	th.AssertEquals(t, timestamp, allMeasures[0].Timestamp)
+	th.AssertEquals(t, float64(42), allMeasures[0].Value)
+
+	allPages, err = measures.ListByResource(client, "generic", resource.ID, "network.incoming.packets.rate", measures.ListOpts{
+		Granularity: gnocchi.Duration(time.Second),
+	}).AllPages()
+	th.AssertNoErr(t, err)
+	allMeasures, err = measures.ExtractMeasures(allPages)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(allMeasures))
+	th.AssertEquals(t, timestamp, allMeasures[0].Timestamp)
+	th.AssertEquals(t, float64(42), allMeasures[0].Value)
+
+	resource, err = resources.Get(client, "generic", resource.ID).Extract()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(resource.Metrics))
+}
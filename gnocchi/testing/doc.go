@@ -0,0 +1,2 @@
+// gnocchi unit tests
+package testing
@@ -0,0 +1,87 @@
+package testing
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/utils/gnocchi"
+)
+
+func TestParseDuration(t *testing.T) {
+	durations := map[string]time.Duration{
+		"3600":             time.Hour,
+		"3600.0":           time.Hour,
+		"0.5":              500 * time.Millisecond,
+		"0:05:00":          5 * time.Minute,
+		"12:00:00":         12 * time.Hour,
+		"1 day, 0:00:00":   24 * time.Hour,
+		"90 days, 0:00:00": 90 * 24 * time.Hour,
+		"0:00:00.500000":   500 * time.Millisecond,
+		"1h":               time.Hour,
+		"1 hour":           time.Hour,
+		"5min":             5 * time.Minute,
+		"1d":               24 * time.Hour,
+		"2 days":           48 * time.Hour,
+		"1h30min":          90 * time.Minute,
+		"1.5h":             90 * time.Minute,
+
+		"106751 days, 0:00:00": 106751 * 24 * time.Hour,
+	}
+
+	for s, expected := range durations {
+		actual, err := gnocchi.ParseDuration(s)
+		th.AssertNoErr(t, err)
+		th.CheckEquals(t, gnocchi.Duration(expected), actual)
+	}
+
+	for _, s := range []string{
+		"", "-60", "1 fortnight", "1:2:3", "one hour",
+		"inf", "-Inf", "NaN", "1e300", "9223372037",
+		"106752 days, 0:00:00", "99999999999999999999 days, 0:00:00", "300000000000000h",
+	} {
+		if _, err := gnocchi.ParseDuration(s); err == nil {
+			t.Errorf("Expected an error for the %q duration", s)
+		}
+	}
+}
+
+func TestDurationString(t *testing.T) {
+	strs := map[time.Duration]string{
+		0:                          "0:00:00",
+		5 * time.Minute:            "0:05:00",
+		time.Hour:                  "1:00:00",
+		24 * time.Hour:             "1 day, 0:00:00",
+		96 * 24 * time.Hour:        "96 days, 0:00:00",
+		500 * time.Millisecond:     "0:00:00.500000",
+		25*time.Hour + time.Second: "1 day, 1:00:01",
+	}
+
+	for d, expected := range strs {
+		th.CheckEquals(t, expected, gnocchi.Duration(d).String())
+	}
+
+	th.CheckEquals(t, "3600", gnocchi.Duration(time.Hour).QueryString())
+	th.CheckEquals(t, "0.5", gnocchi.Duration(500*time.Millisecond).QueryString())
+}
+
+func TestDurationJSON(t *testing.T) {
+	var actual struct {
+		Granularity gnocchi.Duration `json:"granularity"`
+		TimeSpan    gnocchi.Duration `json:"timespan"`
+		Resample    gnocchi.Duration `json:"resample"`
+	}
+	err := json.Unmarshal([]byte(`{"granularity": "1:00:00", "timespan": 86400.0, "resample": null}`), &actual)
+	th.AssertNoErr(t, err)
+	th.CheckEquals(t, gnocchi.Duration(time.Hour), actual.Granularity)
+	th.CheckEquals(t, gnocchi.Duration(24*time.Hour), actual.TimeSpan)
+	th.CheckEquals(t, gnocchi.Duration(0), actual.Resample)
+
+	th.CheckJSONEquals(t, `{"granularity": "1:00:00", "timespan": "1 day, 0:00:00", "resample": "0:00:00"}`, actual)
+
+	err = json.Unmarshal([]byte(`{"granularity": true}`), &actual)
+	if err == nil {
+		t.Fatal("Expected an error for an invalid duration")
+	}
+}
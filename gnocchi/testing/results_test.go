@@ -0,0 +1,27 @@
+package testing
+
+import (
+	"net/http"
+	"testing"
+
+	th "github.com/gophercloud/gophercloud/testhelper"
+	"github.com/gophercloud/utils/gnocchi"
+)
+
+func TestNextLink(t *testing.T) {
+	links := map[string]string{
+		`<http://gnocchi/v1/metric?limit=2&marker=a>; rel="next"`:                                    "http://gnocchi/v1/metric?limit=2&marker=a",
+		`<http://gnocchi/v1/metric?limit=2&marker=a>;rel=next`:                                       "http://gnocchi/v1/metric?limit=2&marker=a",
+		`<http://gnocchi/v1/metric>; rel="first", <http://gnocchi/v1/metric?marker=b>; rel = "next"`: "http://gnocchi/v1/metric?marker=b",
+		`<http://gnocchi/v1/metric>; rel="first"`:                                                    "",
+		`http://gnocchi/v1/metric; rel="next"`:                                                       "",
+	}
+
+	for link, expected := range links {
+		header := http.Header{}
+		header.Set("Link", link)
+		th.AssertEquals(t, expected, gnocchi.NextLink(header))
+	}
+
+	th.AssertEquals(t, "", gnocchi.NextLink(http.Header{}))
+}
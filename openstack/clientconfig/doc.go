@@ -45,5 +45,109 @@ Example to Create a Service Client from clouds.yaml
 		panic(err)
 	}
 
+
+Example to Create Service Clients in All Regions of a Cloud
+
+Entries of the regions section of clouds.yaml are either region names or
+dictionaries with settings that override the cloud entry in the region:
+
+	clouds:
+	  hawaii:
+	    auth:
+	      auth_url: "https://hi.example.com:5000/v3"
+	      username: "jdoe"
+	      password: "password"
+	      project_name: "Project"
+	      user_domain_name: "Default"
+	    regions:
+	      - HNL
+	      - name: OGG
+	        values:
+	          interface: "internal"
+
+	opts := &clientconfig.ClientOpts{
+		Cloud: "hawaii",
+	}
+
+	computeClients, err := clientconfig.NewServiceClients("compute", opts)
+	if err != nil {
+		panic(err)
+	}
+
+	for region, computeClient := range computeClients {
+		fmt.Printf("%s: %s\n", region, computeClient.Endpoint)
+	}
+
+Example to Override a Service Endpoint in clouds.yaml
+
+The interface of the endpoints, the service type and the endpoint of a
+service can be set with the "interface", "<service>_service_type" and
+"<service>_endpoint_override" keys:
+
+	clouds:
+	  hawaii:
+	    auth:
+	      auth_url: "https://hi.example.com:5000/v3"
+	      username: "jdoe"
+	      password: "password"
+	      project_name: "Project"
+	      user_domain_name: "Default"
+	    region_name: "HNL"
+	    interface: "internal"
+	    compute_endpoint_override: "https://nova.hi.example.com/v2.1"
+	    block_storage_api_version: 3
+
+Example to Register a Vendor Profile
+
+Vendor profiles of public clouds like "ovh", "vexxhost" or "citycloud" are
+embedded and used when a profile isn't defined in clouds-public.yaml.
+Additional profiles, for example decoded from openstacksdk vendor files, can
+be registered at runtime:
+
+	err := clientconfig.RegisterVendorProfile(clientconfig.VendorProfile{
+		Name: "mycloud",
+		Profile: clientconfig.Cloud{
+			AuthInfo: &clientconfig.AuthInfo{
+				AuthURL: "https://identity.mycloud.example.com:5000/v3",
+			},
+			IdentityAPIVersion: "3",
+			Regions:            []clientconfig.Region{{Name: "RegionOne"}, {Name: "RegionTwo"}},
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+Example to Edit clouds.yaml
+
+Keys of clouds.yaml that aren't modeled by Cloud are preserved. Passwords,
+tokens and application credential secrets can be moved to secure.yaml:
+
+	cloudsFile, err := clientconfig.ReadCloudsFile("clouds.yaml")
+	if err != nil {
+		panic(err)
+	}
+
+	err = cloudsFile.SetCloud("hawaii", clientconfig.Cloud{
+		AuthInfo: &clientconfig.AuthInfo{
+			AuthURL:        "https://hi.example.com:5000/v3",
+			Username:       "jdoe",
+			Password:       "password",
+			ProjectName:    "Project",
+			UserDomainName: "Default",
+		},
+		RegionName: "HNL",
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	cloudsFile.RemoveCloud("florida")
+
+	err = cloudsFile.WriteWithSecureFile("clouds.yaml", "secure.yaml")
+	if err != nil {
+		panic(err)
+	}
+
 */
 package clientconfig
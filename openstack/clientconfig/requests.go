@@ -1,9 +1,15 @@
 package clientconfig
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"net/http"
 	"os"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/gophercloud/gophercloud"
@@ -119,6 +125,24 @@ func LoadPublicCloudsYAML() (map[string]Cloud, error) {
 
 // GetCloudFromYAML will return a cloud entry from a clouds.yaml file.
 func GetCloudFromYAML(opts *ClientOpts) (*Cloud, error) {
+	cloud, err := loadCloud(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply the values of the requested region if the cloud entry
+	// lists it.
+	regionName := cloud.RegionName
+	if opts != nil && opts.RegionName != "" {
+		regionName = opts.RegionName
+	}
+
+	return cloudForRegion(cloud, regionName)
+}
+
+// loadCloud returns a cloud entry merged from clouds.yaml, secure.yaml and
+// the profile of the entry without the values of its regions.
+func loadCloud(opts *ClientOpts) (*Cloud, error) {
 	clouds, err := LoadCloudsYAML()
 	if err != nil {
 		return nil, fmt.Errorf("unable to load clouds.yaml: %s", err)
@@ -172,13 +196,24 @@ func GetCloudFromYAML(opts *ClientOpts) (*Cloud, error) {
 		return nil, fmt.Errorf("unable to load clouds-public.yaml: %s", err)
 	}
 
-	var profileName = defaultIfEmpty(cloud.Profile, cloud.Cloud)
+	var profileName string
+	if cloud != nil {
+		profileName = defaultIfEmpty(cloud.Profile, cloud.Cloud)
+	}
 	if profileName != "" {
-		publicCloud, ok := publicClouds[profileName]
-		if !ok {
-			return nil, fmt.Errorf("cloud %s does not exist in clouds-public.yaml", profileName)
+		// Profiles from clouds-public.yaml take precedence over
+		// the registered and embedded vendor profiles.
+		var profile interface{}
+		if publicCloud, ok := publicClouds[profileName]; ok {
+			profile = publicCloud
+		} else {
+			vendorProfile, err := LoadVendorProfile(profileName)
+			if err != nil {
+				return nil, fmt.Errorf("cloud %s does not exist in clouds-public.yaml or vendor profiles", profileName)
+			}
+			profile = vendorProfile
 		}
-		cloud, err = mergeClouds(cloud, publicCloud)
+		cloud, err = mergeClouds(cloud, profile)
 		if err != nil {
 			return nil, fmt.Errorf("Could not merge information from clouds.yaml and clouds-public.yaml for cloud %s", profileName)
 		}
@@ -217,15 +252,35 @@ func GetCloudFromYAML(opts *ClientOpts) (*Cloud, error) {
 		}
 	}
 
+	return cloud, nil
+}
+
+// cloudForRegion applies the values of the region to the cloud entry if the
+// entry lists the region, and sets the defaults that depend on the region.
+func cloudForRegion(cloud *Cloud, regionName string) (*Cloud, error) {
+	if regionName != "" && len(cloud.Regions) > 0 {
+		for _, region := range cloud.Regions {
+			if region.Name == regionName {
+				var err error
+				cloud, err = cloud.ForRegion(regionName)
+				if err != nil {
+					return nil, err
+				}
+				break
+			}
+		}
+	}
+
 	// Default is to verify SSL API requests
 	if cloud.Verify == nil {
 		iTrue := true
 		cloud.Verify = &iTrue
 	}
 
-	// TODO: this is where reading vendor files should go be considered when not found in
-	// clouds-public.yml
-	// https://github.com/openstack/openstacksdk/tree/master/openstack/config/vendors
+	// Some vendor profiles use the region name in the auth URL.
+	if cloud.AuthInfo != nil && cloud.RegionName != "" {
+		cloud.AuthInfo.AuthURL = strings.Replace(cloud.AuthInfo.AuthURL, "{region_name}", cloud.RegionName, -1)
+	}
 
 	return cloud, nil
 }
@@ -239,13 +294,25 @@ func GetCloudFromYAML(opts *ClientOpts) (*Cloud, error) {
 // See http://docs.openstack.org/developer/os-client-config and
 // https://github.com/openstack/os-client-config/blob/master/os_client_config/config.py.
 func AuthOptions(opts *ClientOpts) (*gophercloud.AuthOptions, error) {
-	cloud := new(Cloud)
-
 	// If no opts were passed in, create an empty ClientOpts.
 	if opts == nil {
 		opts = new(ClientOpts)
 	}
 
+	cloud, _, err := cloudFromOpts(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return authOptions(cloud, opts)
+}
+
+// cloudFromOpts returns the cloud entry requested by the ClientOpts or the
+// CLOUD environment variable, and the prefix of environment variables. An
+// empty cloud entry is returned if no cloud was requested.
+func cloudFromOpts(opts *ClientOpts) (*Cloud, string, error) {
+	cloud := new(Cloud)
+
 	// Determine if a clouds.yaml entry should be retrieved.
 	// Start by figuring out the cloud name.
 	// First check if one was explicitly specified in opts.
@@ -270,10 +337,16 @@ func AuthOptions(opts *ClientOpts) (*gophercloud.AuthOptions, error) {
 		var err error
 		cloud, err = GetCloudFromYAML(opts)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 	}
 
+	return cloud, envPrefix, nil
+}
+
+// authOptions creates a gophercloud.AuthOptions structure with the settings
+// of the cloud entry, ClientOpts and environment variables.
+func authOptions(cloud *Cloud, opts *ClientOpts) (*gophercloud.AuthOptions, error) {
 	// If cloud.AuthInfo is nil, then no cloud was specified.
 	if cloud.AuthInfo == nil {
 		// If opts.Auth is not nil, then try using the auth settings from it.
@@ -612,58 +685,180 @@ func v3auth(cloud *Cloud, opts *ClientOpts) (*gophercloud.AuthOptions, error) {
 // AuthenticatedClient is a convenience function to get a new provider client
 // based on a clouds.yaml entry.
 func AuthenticatedClient(opts *ClientOpts) (*gophercloud.ProviderClient, error) {
-	ao, err := AuthOptions(opts)
+	// If no opts were passed in, create an empty ClientOpts.
+	if opts == nil {
+		opts = new(ClientOpts)
+	}
+
+	cloud, envPrefix, err := cloudFromOpts(opts)
 	if err != nil {
 		return nil, err
 	}
 
-	return openstack.AuthenticatedClient(*ao)
+	return authenticatedClient(cloud, opts, envPrefix)
 }
 
-// NewServiceClient is a convenience function to get a new service client.
-func NewServiceClient(service string, opts *ClientOpts) (*gophercloud.ServiceClient, error) {
-	cloud := new(Cloud)
+// authenticatedClient returns a new ProviderClient that is authenticated with
+// the settings of the cloud entry.
+func authenticatedClient(cloud *Cloud, opts *ClientOpts, envPrefix string) (*gophercloud.ProviderClient, error) {
+	ao, err := authOptions(cloud, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient, err := httpClient(cloud, envPrefix)
+	if err != nil {
+		return nil, err
+	}
 
+	pClient, err := openstack.NewClient(ao.IdentityEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	pClient.HTTPClient = *httpClient
+
+	err = openstack.Authenticate(pClient, *ao)
+	if err != nil {
+		return nil, err
+	}
+
+	return pClient, nil
+}
+
+// HTTPClient is a convenience function to get a new HTTP client that uses
+// the TLS settings of a clouds.yaml entry. The "verify", "cacert", "cert"
+// and "key" settings of the cloud entry take precedence over the INSECURE,
+// CACERT, CERT and KEY environment variables. Since requests to a cloud
+// entry are verified by default, INSECURE only applies when clouds.yaml
+// isn't used.
+//
+// If neither of the settings are set, the returned client uses the
+// default transport. Otherwise, it uses a copy of the default transport with
+// the TLS settings.
+func HTTPClient(opts *ClientOpts) (*http.Client, error) {
 	// If no opts were passed in, create an empty ClientOpts.
 	if opts == nil {
 		opts = new(ClientOpts)
 	}
 
-	// Determine if a clouds.yaml entry should be retrieved.
-	// Start by figuring out the cloud name.
-	// First check if one was explicitly specified in opts.
-	var cloudName string
-	if opts.Cloud != "" {
-		cloudName = opts.Cloud
+	cloud, envPrefix, err := cloudFromOpts(opts)
+	if err != nil {
+		return nil, err
 	}
 
-	// Next see if a cloud name was specified as an environment variable.
-	envPrefix := "OS_"
-	if opts.EnvPrefix != "" {
-		envPrefix = opts.EnvPrefix
+	return httpClient(cloud, envPrefix)
+}
+
+// httpClient returns a new HTTP client with the TLS settings of the cloud
+// entry and environment variables.
+func httpClient(cloud *Cloud, envPrefix string) (*http.Client, error) {
+	config, err := tlsConfig(cloud, envPrefix)
+	if err != nil {
+		return nil, err
 	}
 
-	if v := os.Getenv(envPrefix + "CLOUD"); v != "" {
-		cloudName = v
+	if config == nil {
+		return &http.Client{}, nil
 	}
 
-	// If a cloud name was determined, try to look it up in clouds.yaml.
-	if cloudName != "" {
-		// Get the requested cloud.
+	return &http.Client{Transport: newTransport(config)}, nil
+}
+
+// tlsConfig builds a TLS configuration from the settings of a cloud entry
+// and environment variables. A nil configuration is returned when no TLS
+// settings were found.
+func tlsConfig(cloud *Cloud, envPrefix string) (*tls.Config, error) {
+	insecure := false
+	if cloud.Verify != nil {
+		insecure = !*cloud.Verify
+	} else if v := os.Getenv(envPrefix + "INSECURE"); v != "" {
 		var err error
-		cloud, err = GetCloudFromYAML(opts)
+		insecure, err = strconv.ParseBool(v)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("invalid value of %sINSECURE: %s", envPrefix, v)
+		}
+	}
+
+	caCertFile := defaultIfEmpty(cloud.CACertFile, os.Getenv(envPrefix+"CACERT"))
+	clientCertFile := defaultIfEmpty(cloud.ClientCertFile, os.Getenv(envPrefix+"CERT"))
+	clientKeyFile := defaultIfEmpty(cloud.ClientKeyFile, os.Getenv(envPrefix+"KEY"))
+
+	if !insecure && caCertFile == "" && clientCertFile == "" && clientKeyFile == "" {
+		return nil, nil
+	}
+
+	config := &tls.Config{
+		InsecureSkipVerify: insecure,
+	}
+
+	if caCertFile != "" {
+		caCert, err := ioutil.ReadFile(caCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read CA certificate: %s", err)
+		}
+
+		caCertPool := x509.NewCertPool()
+		if ok := caCertPool.AppendCertsFromPEM(caCert); !ok {
+			return nil, fmt.Errorf("no certificates found in %s", caCertFile)
+		}
+		config.RootCAs = caCertPool
+	}
+
+	if clientCertFile != "" {
+		clientCert, err := ioutil.ReadFile(clientCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read client certificate: %s", err)
 		}
+
+		// The client certificate file may also contain the key.
+		clientKey := clientCert
+		if clientKeyFile != "" {
+			clientKey, err = ioutil.ReadFile(clientKeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read client key: %s", err)
+			}
+		}
+
+		cert, err := tls.X509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate: %s", err)
+		}
+
+		config.Certificates = []tls.Certificate{cert}
+	} else if clientKeyFile != "" {
+		return nil, fmt.Errorf("a client key was specified without a client certificate")
+	}
+
+	return config, nil
+}
+
+// NewServiceClient is a convenience function to get a new service client.
+func NewServiceClient(service string, opts *ClientOpts) (*gophercloud.ServiceClient, error) {
+	// If no opts were passed in, create an empty ClientOpts.
+	if opts == nil {
+		opts = new(ClientOpts)
+	}
+
+	cloud, envPrefix, err := cloudFromOpts(opts)
+	if err != nil {
+		return nil, err
 	}
 
 	// Get a Provider Client
-	pClient, err := AuthenticatedClient(opts)
+	pClient, err := authenticatedClient(cloud, opts, envPrefix)
 	if err != nil {
 		return nil, err
 	}
 
-	// Determine the region to use.
+	region := regionName(cloud, opts, envPrefix)
+
+	return serviceClient(pClient, service, cloud, region, envPrefix)
+}
+
+// regionName determines the region to use from the REGION_NAME environment
+// variable, the cloud entry and the ClientOpts.
+func regionName(cloud *Cloud, opts *ClientOpts, envPrefix string) string {
 	// First, check if the REGION_NAME environment variable is set.
 	var region string
 	if v := os.Getenv(envPrefix + "REGION_NAME"); v != "" {
@@ -681,66 +876,245 @@ func NewServiceClient(service string, opts *ClientOpts) (*gophercloud.ServiceCli
 		region = v
 	}
 
+	return region
+}
+
+// serviceClient returns a new service client in the region that uses the
+// endpoint settings of the cloud entry.
+func serviceClient(pClient *gophercloud.ProviderClient, service string, cloud *Cloud, region, envPrefix string) (*gophercloud.ServiceClient, error) {
 	eo := gophercloud.EndpointOpts{
 		Region: region,
 	}
 
+	// Determine the interface of service catalog endpoints to use.
+	// First, check if the INTERFACE environment variable is set.
+	var endpointInterface string
+	if v := os.Getenv(envPrefix + "INTERFACE"); v != "" {
+		endpointInterface = v
+	}
+
+	// Next, check if the cloud entry sets an interface.
+	if v := cloud.Interface; v != "" {
+		endpointInterface = v
+	}
+
+	if endpointInterface != "" {
+		// Legacy interfaces have an "URL" suffix, e.g. "publicURL".
+		availability := gophercloud.Availability(strings.TrimSuffix(endpointInterface, "URL"))
+		switch availability {
+		case gophercloud.AvailabilityPublic, gophercloud.AvailabilityInternal, gophercloud.AvailabilityAdmin:
+			eo.Availability = availability
+		default:
+			return nil, fmt.Errorf("invalid interface: %s", endpointInterface)
+		}
+	}
+
+	if v := cloud.ServiceType(service); v != "" {
+		eo.Type = v
+	}
+
+	clientType, err := serviceClientTypeFor(service, cloud)
+	if err != nil {
+		return nil, err
+	}
+
+	// An endpoint override is used instead of the service catalog. The
+	// service client is built directly, so the ProviderClient that can be
+	// shared by several service clients isn't modified.
+	if v := cloud.EndpointOverride(service); v != "" {
+		endpoint := gophercloud.NormalizeURL(v)
+		client := &gophercloud.ServiceClient{
+			ProviderClient: pClient,
+			Endpoint:       endpoint,
+			Type:           clientType.name,
+		}
+		if clientType.resourceVersion != "" {
+			client.ResourceBase = endpoint + clientType.resourceVersion
+		}
+		return client, nil
+	}
+
+	return clientType.newClient(pClient, eo)
+}
+
+// serviceClientType describes how service clients of a service are created.
+type serviceClientType struct {
+	// newClient creates a service client with an endpoint from the
+	// service catalog.
+	newClient func(*gophercloud.ProviderClient, gophercloud.EndpointOpts) (*gophercloud.ServiceClient, error)
+
+	// name is the type of the service client.
+	name string
+
+	// resourceVersion is the API version path that the service client
+	// appends to the endpoint to build the base URL of resources.
+	resourceVersion string
+}
+
+// serviceClientTypeFor returns the type of service clients of the service
+// with the API version set in the cloud entry.
+func serviceClientTypeFor(service string, cloud *Cloud) (serviceClientType, error) {
 	switch service {
 	case "clustering":
-		return openstack.NewClusteringV1(pClient, eo)
+		return serviceClientType{newClient: openstack.NewClusteringV1, name: "clustering"}, nil
 	case "compute":
-		return openstack.NewComputeV2(pClient, eo)
+		return serviceClientType{newClient: openstack.NewComputeV2, name: "compute"}, nil
 	case "container":
-		return openstack.NewContainerV1(pClient, eo)
+		return serviceClientType{newClient: openstack.NewContainerV1, name: "container"}, nil
 	case "database":
-		return openstack.NewDBV1(pClient, eo)
+		return serviceClientType{newClient: openstack.NewDBV1, name: "database"}, nil
 	case "dns":
-		return openstack.NewDNSV2(pClient, eo)
+		return serviceClientType{newClient: openstack.NewDNSV2, name: "dns", resourceVersion: "v2/"}, nil
 	case "identity":
 		identityVersion := "3"
-		if v := cloud.IdentityAPIVersion; v != "" {
+		if v := cloud.APIVersion(service); v != "" {
 			identityVersion = v
 		}
 
 		switch identityVersion {
 		case "v2", "2", "2.0":
-			return openstack.NewIdentityV2(pClient, eo)
+			return serviceClientType{newClient: openstack.NewIdentityV2, name: "identity"}, nil
 		case "v3", "3":
-			return openstack.NewIdentityV3(pClient, eo)
+			return serviceClientType{newClient: openstack.NewIdentityV3, name: "identity"}, nil
 		default:
-			return nil, fmt.Errorf("invalid identity API version")
+			return serviceClientType{}, fmt.Errorf("invalid identity API version")
 		}
 	case "image":
-		return openstack.NewImageServiceV2(pClient, eo)
+		return serviceClientType{newClient: openstack.NewImageServiceV2, name: "image", resourceVersion: "v2/"}, nil
 	case "load-balancer":
-		return openstack.NewLoadBalancerV2(pClient, eo)
+		return serviceClientType{newClient: openstack.NewLoadBalancerV2, name: "load-balancer", resourceVersion: "v2.0/"}, nil
 	case "network":
-		return openstack.NewNetworkV2(pClient, eo)
+		return serviceClientType{newClient: openstack.NewNetworkV2, name: "network", resourceVersion: "v2.0/"}, nil
 	case "object-store":
-		return openstack.NewObjectStorageV1(pClient, eo)
+		return serviceClientType{newClient: openstack.NewObjectStorageV1, name: "object-store"}, nil
 	case "orchestration":
-		return openstack.NewOrchestrationV1(pClient, eo)
+		return serviceClientType{newClient: openstack.NewOrchestrationV1, name: "orchestration"}, nil
 	case "sharev2":
-		return openstack.NewSharedFileSystemV2(pClient, eo)
+		return serviceClientType{newClient: openstack.NewSharedFileSystemV2, name: "sharev2"}, nil
 	case "volume":
 		volumeVersion := "2"
-		if v := cloud.VolumeAPIVersion; v != "" {
+		if v := cloud.APIVersion(service); v != "" {
 			volumeVersion = v
 		}
 
 		switch volumeVersion {
 		case "v1", "1":
-			return openstack.NewBlockStorageV1(pClient, eo)
+			return serviceClientType{newClient: openstack.NewBlockStorageV1, name: "volume"}, nil
 		case "v2", "2":
-			return openstack.NewBlockStorageV2(pClient, eo)
+			return serviceClientType{newClient: openstack.NewBlockStorageV2, name: "volumev2"}, nil
 		case "v3", "3":
-			return openstack.NewBlockStorageV3(pClient, eo)
+			return serviceClientType{newClient: openstack.NewBlockStorageV3, name: "volumev3"}, nil
 		default:
-			return nil, fmt.Errorf("invalid volume API version")
+			return serviceClientType{}, fmt.Errorf("invalid volume API version")
 		}
 	}
 
-	return nil, fmt.Errorf("unable to create a service client for %s", service)
+	return serviceClientType{}, fmt.Errorf("unable to create a service client for %s", service)
+}
+
+// authKey returns a key of the settings of the cloud entry that a
+// ProviderClient is authenticated with: the auth section, the auth type,
+// the Identity API version and the TLS settings.
+func authKey(cloud *Cloud) (string, error) {
+	b, err := json.Marshal(struct {
+		AuthInfo           *AuthInfo `json:"auth"`
+		AuthType           AuthType  `json:"auth_type"`
+		IdentityAPIVersion string    `json:"identity_api_version"`
+		Verify             *bool     `json:"verify"`
+		CACertFile         string    `json:"cacert"`
+		ClientCertFile     string    `json:"cert"`
+		ClientKeyFile      string    `json:"key"`
+	}{
+		AuthInfo:           cloud.AuthInfo,
+		AuthType:           cloud.AuthType,
+		IdentityAPIVersion: cloud.IdentityAPIVersion,
+		Verify:             cloud.Verify,
+		CACertFile:         cloud.CACertFile,
+		ClientCertFile:     cloud.ClientCertFile,
+		ClientKeyFile:      cloud.ClientKeyFile,
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to encode auth settings: %s", err)
+	}
+
+	return string(b), nil
+}
+
+// NewServiceClients is a convenience function to get a new service client in
+// every region of a cloud entry. The service clients are keyed by region
+// names. If the cloud entry doesn't list regions, a single service client
+// in the region from the ClientOpts, the cloud entry or the REGION_NAME
+// environment variable is returned.
+//
+// The cloud entry is loaded once and the service clients share an
+// authenticated ProviderClient. Only a region that overrides the auth or
+// TLS settings of the cloud entry is authenticated separately.
+func NewServiceClients(service string, opts *ClientOpts) (map[string]*gophercloud.ServiceClient, error) {
+	// If no opts were passed in, create an empty ClientOpts.
+	if opts == nil {
+		opts = new(ClientOpts)
+	}
+
+	envPrefix := "OS_"
+	if opts.EnvPrefix != "" {
+		envPrefix = opts.EnvPrefix
+	}
+
+	cloud, err := loadCloud(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cloud.checkRegionNames(); err != nil {
+		return nil, err
+	}
+
+	// Without the regions section, the region is determined the same way
+	// as in NewServiceClient.
+	regions := cloud.Regions
+	if len(regions) == 0 {
+		name := regionName(cloud, opts, envPrefix)
+		if name == "" {
+			return nil, fmt.Errorf("no regions found for the cloud")
+		}
+		regions = []Region{{Name: name}}
+	}
+
+	// Regions usually share the identity service and credentials, so a
+	// ProviderClient is only authenticated again for a region with
+	// different auth or TLS settings.
+	pClients := make(map[string]*gophercloud.ProviderClient)
+
+	clients := make(map[string]*gophercloud.ServiceClient, len(regions))
+	for _, region := range regions {
+		regionCloud, err := cloudForRegion(cloud, region.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := authKey(regionCloud)
+		if err != nil {
+			return nil, err
+		}
+
+		pClient, ok := pClients[key]
+		if !ok {
+			pClient, err = authenticatedClient(regionCloud, opts, envPrefix)
+			if err != nil {
+				return nil, fmt.Errorf("unable to authenticate in region %s: %s", region.Name, err)
+			}
+			pClients[key] = pClient
+		}
+
+		client, err := serviceClient(pClient, service, regionCloud, region.Name, envPrefix)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create a service client in region %s: %s", region.Name, err)
+		}
+
+		clients[region.Name] = client
+	}
+
+	return clients, nil
 }
 
 // isProjectScoped determines if an auth struct is project scoped.
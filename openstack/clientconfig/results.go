@@ -1,5 +1,14 @@
 package clientconfig
 
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
 // PublicClouds represents a collection of PublicCloud entries in clouds-public.yaml file.
 // The format of the clouds-public.yml is documented at
 // https://docs.openstack.org/python-openstackclient/latest/configuration/
@@ -16,106 +25,478 @@ type Clouds struct {
 
 // Cloud represents an entry in a clouds.yaml/public-clouds.yaml/secure.yaml file.
 type Cloud struct {
-	Cloud      string        `yaml:"cloud" json:"cloud"`
-	Profile    string        `yaml:"profile" json:"profile"`
-	AuthInfo   *AuthInfo     `yaml:"auth" json:"auth"`
-	AuthType   AuthType      `yaml:"auth_type" json:"auth_type"`
-	RegionName string        `yaml:"region_name" json:"region_name"`
-	Regions    []interface{} `yaml:"regions" json:"regions"`
+	Cloud      string    `yaml:"cloud,omitempty" json:"cloud"`
+	Profile    string    `yaml:"profile,omitempty" json:"profile"`
+	AuthInfo   *AuthInfo `yaml:"auth,omitempty" json:"auth"`
+	AuthType   AuthType  `yaml:"auth_type,omitempty" json:"auth_type"`
+	RegionName string    `yaml:"region_name,omitempty" json:"region_name"`
+	Regions    []Region  `yaml:"regions,omitempty" json:"regions"`
+
+	// Interface is the interface of service catalog endpoints to use:
+	// "public", "internal" or "admin".
+	Interface string `yaml:"interface,omitempty" json:"interface"`
 
 	// API Version overrides.
-	IdentityAPIVersion string `yaml:"identity_api_version" json:"identity_api_version"`
-	VolumeAPIVersion   string `yaml:"volume_api_version" json:"volume_api_version"`
+	IdentityAPIVersion     string `yaml:"identity_api_version,omitempty" json:"identity_api_version"`
+	VolumeAPIVersion       string `yaml:"volume_api_version,omitempty" json:"volume_api_version"`
+	BlockStorageAPIVersion string `yaml:"block_storage_api_version,omitempty" json:"block_storage_api_version"`
+
+	// APIVersions contains API versions of other services keyed by
+	// a service name. They are set with "<service>_api_version" keys.
+	APIVersions map[string]string `yaml:"-" json:"-"`
+
+	// EndpointOverrides contains endpoints that are used instead of
+	// service catalog endpoints keyed by a service name. They are set with
+	// "<service>_endpoint_override" keys.
+	EndpointOverrides map[string]string `yaml:"-" json:"-"`
+
+	// ServiceTypes contains service catalog types keyed by a service name.
+	// They are set with "<service>_service_type" keys.
+	ServiceTypes map[string]string `yaml:"-" json:"-"`
+
+	// FloatingIPSource is the source of floating IPs: "neutron", "nova"
+	// or "None".
+	FloatingIPSource string `yaml:"floating_ip_source,omitempty" json:"floating_ip_source"`
+
+	// Networks describes how networks of the cloud are used.
+	Networks []Network `yaml:"networks,omitempty" json:"networks"`
 
 	// Verify whether or not SSL API requests should be verified.
-	Verify *bool `yaml:"verify" json:"verify"`
+	Verify *bool `yaml:"verify,omitempty" json:"verify"`
 
 	// CACertFile a path to a CA Cert bundle that can be used as part of
 	// verifying SSL API requests.
-	CACertFile string `yaml:"cacert" json:"cacert"`
+	CACertFile string `yaml:"cacert,omitempty" json:"cacert"`
 
 	// ClientCertFile a path to a client certificate to use as part of the SSL
 	// transaction.
-	ClientCertFile string `yaml:"cert" json:"cert"`
+	ClientCertFile string `yaml:"cert,omitempty" json:"cert"`
 
 	// ClientKeyFile a path to a client key to use as part of the SSL
 	// transaction.
-	ClientKeyFile string `yaml:"key" json:"key"`
+	ClientKeyFile string `yaml:"key,omitempty" json:"key"`
+}
+
+const (
+	apiVersionSuffix       = "_api_version"
+	endpointOverrideSuffix = "_endpoint_override"
+	serviceTypeSuffix      = "_service_type"
+)
+
+// cloud is used to decode and encode the modeled keys of a Cloud.
+type cloud Cloud
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface. It collects
+// "<service>_*" keys of a cloud entry.
+func (r *Cloud) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s cloud
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	var raw map[string]interface{}
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+
+	*r = Cloud(s)
+	r.setServiceOptions(raw)
+
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It collects
+// "<service>_*" keys of a cloud entry.
+func (r *Cloud) UnmarshalJSON(b []byte) error {
+	var s cloud
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	*r = Cloud(s)
+	r.setServiceOptions(raw)
+
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface. It adds
+// "<service>_*" keys to a cloud entry.
+func (r Cloud) MarshalYAML() (interface{}, error) {
+	options := r.serviceOptions()
+	if len(options) == 0 {
+		return cloud(r), nil
+	}
+
+	b, err := yaml.Marshal(cloud(r))
+	if err != nil {
+		return nil, err
+	}
+
+	var m yaml.MapSlice
+	if err := yaml.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(options))
+	for k := range options {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		m = append(m, yaml.MapItem{Key: k, Value: options[k]})
+	}
+
+	return m, nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. It adds
+// "<service>_*" keys to a cloud entry.
+func (r Cloud) MarshalJSON() ([]byte, error) {
+	b, err := json.Marshal(cloud(r))
+	if err != nil {
+		return nil, err
+	}
+
+	options := r.serviceOptions()
+	if len(options) == 0 {
+		return b, nil
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, err
+	}
+
+	for k, v := range options {
+		m[k] = v
+	}
+
+	return json.Marshal(m)
+}
+
+// setServiceOptions populates per-service options from keys of a cloud entry.
+func (r *Cloud) setServiceOptions(raw map[string]interface{}) {
+	for key, v := range raw {
+		var value string
+		switch v.(type) {
+		case string, int, float64:
+			value = fmt.Sprint(v)
+		default:
+			continue
+		}
+
+		switch {
+		case key == "identity_api_version", key == "volume_api_version", key == "block_storage_api_version":
+			// These keys are modeled by the Cloud fields.
+		case strings.HasSuffix(key, apiVersionSuffix):
+			r.APIVersions = setServiceOption(r.APIVersions, strings.TrimSuffix(key, apiVersionSuffix), value)
+		case strings.HasSuffix(key, endpointOverrideSuffix):
+			r.EndpointOverrides = setServiceOption(r.EndpointOverrides, strings.TrimSuffix(key, endpointOverrideSuffix), value)
+		case strings.HasSuffix(key, serviceTypeSuffix):
+			r.ServiceTypes = setServiceOption(r.ServiceTypes, strings.TrimSuffix(key, serviceTypeSuffix), value)
+		}
+	}
+}
+
+// serviceOptions returns per-service options as keys of a cloud entry.
+func (r Cloud) serviceOptions() map[string]string {
+	options := make(map[string]string)
+	for service, v := range r.APIVersions {
+		options[service+apiVersionSuffix] = v
+	}
+	for service, v := range r.EndpointOverrides {
+		options[service+endpointOverrideSuffix] = v
+	}
+	for service, v := range r.ServiceTypes {
+		options[service+serviceTypeSuffix] = v
+	}
+	return options
+}
+
+// setServiceOption sets an option of the service and allocates the map of
+// options if needed.
+func setServiceOption(options map[string]string, service, value string) map[string]string {
+	if options == nil {
+		options = make(map[string]string)
+	}
+	options[service] = value
+	return options
+}
+
+// serviceKeys returns names that are used for the service in keys of
+// a cloud entry.
+func serviceKeys(service string) []string {
+	key := strings.Replace(service, "-", "_", -1)
+	switch key {
+	case "volume", "block_storage":
+		return []string{"volume", "block_storage"}
+	case "sharev2", "shared_file_system":
+		return []string{"sharev2", "shared_file_system"}
+	}
+	return []string{key}
+}
+
+// lookupServiceOption returns an option of the service using any of its names.
+func lookupServiceOption(options map[string]string, service string) string {
+	for _, key := range serviceKeys(service) {
+		if v, ok := options[key]; ok {
+			return v
+		}
+	}
+	return ""
+}
+
+// APIVersion returns the API version of the service set in the cloud entry.
+// Service names are the same as in the NewServiceClient function.
+func (r Cloud) APIVersion(service string) string {
+	switch serviceKeys(service)[0] {
+	case "identity":
+		if r.IdentityAPIVersion != "" {
+			return r.IdentityAPIVersion
+		}
+	case "volume":
+		if v := defaultIfEmpty(r.VolumeAPIVersion, r.BlockStorageAPIVersion); v != "" {
+			return v
+		}
+	}
+	return lookupServiceOption(r.APIVersions, service)
+}
+
+// EndpointOverride returns the endpoint of the service that should be used
+// instead of the service catalog.
+func (r Cloud) EndpointOverride(service string) string {
+	return lookupServiceOption(r.EndpointOverrides, service)
+}
+
+// ServiceType returns the service catalog type of the service set in the
+// cloud entry.
+func (r Cloud) ServiceType(service string) string {
+	return lookupServiceOption(r.ServiceTypes, service)
+}
+
+// ForRegion returns a copy of the cloud entry with the region name set and
+// the values of the region overriding the settings of the cloud entry.
+func (r Cloud) ForRegion(name string) (*Cloud, error) {
+	if err := r.checkRegionNames(); err != nil {
+		return nil, err
+	}
+
+	for _, region := range r.Regions {
+		if region.Name != name {
+			continue
+		}
+
+		values := region.Values
+		if values == nil {
+			values = new(Cloud)
+		}
+
+		cloud, err := mergeClouds(values, r)
+		if err != nil {
+			return nil, fmt.Errorf("unable to merge values of region %s: %s", name, err)
+		}
+
+		// Lists are appended by mergeClouds, but networks of the region
+		// replace networks of the cloud.
+		if len(values.Networks) > 0 {
+			cloud.Networks = values.Networks
+		}
+
+		cloud.RegionName = name
+
+		return cloud, nil
+	}
+
+	return nil, fmt.Errorf("region %s does not exist", name)
+}
+
+// checkRegionNames returns an error if an entry of the regions section of
+// the cloud entry has no name.
+func (r Cloud) checkRegionNames() error {
+	for i, region := range r.Regions {
+		if region.Name == "" {
+			return fmt.Errorf("region %d of the cloud has no name", i)
+		}
+	}
+	return nil
+}
+
+// Region represents an entry of the regions section of a cloud entry.
+type Region struct {
+	// Name is the name of the region.
+	Name string `yaml:"name" json:"name"`
+
+	// Values contains settings of the cloud entry that are overridden in
+	// the region.
+	Values *Cloud `yaml:"values,omitempty" json:"values,omitempty"`
+}
+
+// region is used to decode and encode dictionary entries of the regions
+// section.
+type region Region
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface. Entries of the
+// regions section are either region names or dictionaries with "name" and
+// "values" keys.
+func (r *Region) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var name string
+	if err := unmarshal(&name); err == nil {
+		*r = Region{Name: name}
+		return nil
+	}
+
+	var s region
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	*r = Region(s)
+
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Entries of the
+// regions section are either region names or objects with "name" and
+// "values" keys.
+func (r *Region) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		*r = Region{Name: name}
+		return nil
+	}
+
+	var s region
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	*r = Region(s)
+
+	return nil
+}
+
+// MarshalYAML implements the yaml.Marshaler interface. A region without
+// values is encoded as its name.
+func (r Region) MarshalYAML() (interface{}, error) {
+	if r.Values == nil {
+		return r.Name, nil
+	}
+	return region(r), nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. A region without
+// values is encoded as its name.
+func (r Region) MarshalJSON() ([]byte, error) {
+	if r.Values == nil {
+		return json.Marshal(r.Name)
+	}
+	return json.Marshal(region(r))
+}
+
+// Network represents an entry of the networks section of a cloud entry.
+type Network struct {
+	// Name is the name or ID of the network.
+	Name string `yaml:"name,omitempty" json:"name"`
+
+	// RoutesExternally shows whether the network is attached to a router
+	// that routes traffic outside of the cloud.
+	RoutesExternally bool `yaml:"routes_externally,omitempty" json:"routes_externally"`
+
+	// RoutesIPv4Externally overrides RoutesExternally for IPv4 traffic.
+	RoutesIPv4Externally *bool `yaml:"routes_ipv4_externally,omitempty" json:"routes_ipv4_externally"`
+
+	// RoutesIPv6Externally overrides RoutesExternally for IPv6 traffic.
+	RoutesIPv6Externally *bool `yaml:"routes_ipv6_externally,omitempty" json:"routes_ipv6_externally"`
+
+	// NATDestination shows whether floating IPs should be attached to
+	// ports of the network.
+	NATDestination bool `yaml:"nat_destination,omitempty" json:"nat_destination"`
+
+	// NATSource shows whether the network is the source of floating IPs.
+	NATSource bool `yaml:"nat_source,omitempty" json:"nat_source"`
+
+	// DefaultInterface shows whether the network is the default one for
+	// new servers.
+	DefaultInterface bool `yaml:"default_interface,omitempty" json:"default_interface"`
 }
 
 // AuthInfo represents the auth section of a cloud entry or
 // auth options entered explicitly in ClientOpts.
 type AuthInfo struct {
 	// AuthURL is the keystone/identity endpoint URL.
-	AuthURL string `yaml:"auth_url" json:"auth_url"`
+	AuthURL string `yaml:"auth_url,omitempty" json:"auth_url"`
 
 	// Token is a pre-generated authentication token.
-	Token string `yaml:"token" json:"token"`
+	Token string `yaml:"token,omitempty" json:"token"`
 
 	// Username is the username of the user.
-	Username string `yaml:"username" json:"username"`
+	Username string `yaml:"username,omitempty" json:"username"`
 
 	// UserID is the unique ID of a user.
-	UserID string `yaml:"user_id" json:"user_id"`
+	UserID string `yaml:"user_id,omitempty" json:"user_id"`
 
 	// Password is the password of the user.
-	Password string `yaml:"password" json:"password"`
+	Password string `yaml:"password,omitempty" json:"password"`
 
 	// Application Credential ID to login with.
-	ApplicationCredentialID string `yaml:"application_credential_id" json:"application_credential_id"`
+	ApplicationCredentialID string `yaml:"application_credential_id,omitempty" json:"application_credential_id"`
 
 	// Application Credential name to login with.
-	ApplicationCredentialName string `yaml:"application_credential_name" json:"application_credential_name"`
+	ApplicationCredentialName string `yaml:"application_credential_name,omitempty" json:"application_credential_name"`
 
 	// Application Credential secret to login with.
-	ApplicationCredentialSecret string `yaml:"application_credential_secret" json:"application_credential_secret"`
+	ApplicationCredentialSecret string `yaml:"application_credential_secret,omitempty" json:"application_credential_secret"`
 
 	// ProjectName is the common/human-readable name of a project.
 	// Users can be scoped to a project.
 	// ProjectName on its own is not enough to ensure a unique scope. It must
 	// also be combined with either a ProjectDomainName or ProjectDomainID.
 	// ProjectName cannot be combined with ProjectID in a scope.
-	ProjectName string `yaml:"project_name" json:"project_name"`
+	ProjectName string `yaml:"project_name,omitempty" json:"project_name"`
 
 	// ProjectID is the unique ID of a project.
 	// It can be used to scope a user to a specific project.
-	ProjectID string `yaml:"project_id" json:"project_id"`
+	ProjectID string `yaml:"project_id,omitempty" json:"project_id"`
 
 	// UserDomainName is the name of the domain where a user resides.
 	// It is used to identify the source domain of a user.
-	UserDomainName string `yaml:"user_domain_name" json:"user_domain_name"`
+	UserDomainName string `yaml:"user_domain_name,omitempty" json:"user_domain_name"`
 
 	// UserDomainID is the unique ID of the domain where a user resides.
 	// It is used to identify the source domain of a user.
-	UserDomainID string `yaml:"user_domain_id" json:"user_domain_id"`
+	UserDomainID string `yaml:"user_domain_id,omitempty" json:"user_domain_id"`
 
 	// ProjectDomainName is the name of the domain where a project resides.
 	// It is used to identify the source domain of a project.
 	// ProjectDomainName can be used in addition to a ProjectName when scoping
 	// a user to a specific project.
-	ProjectDomainName string `yaml:"project_domain_name" json:"project_domain_name"`
+	ProjectDomainName string `yaml:"project_domain_name,omitempty" json:"project_domain_name"`
 
 	// ProjectDomainID is the name of the domain where a project resides.
 	// It is used to identify the source domain of a project.
 	// ProjectDomainID can be used in addition to a ProjectName when scoping
 	// a user to a specific project.
-	ProjectDomainID string `yaml:"project_domain_id" json:"project_domain_id"`
+	ProjectDomainID string `yaml:"project_domain_id,omitempty" json:"project_domain_id"`
 
 	// DomainName is the name of a domain which can be used to identify the
 	// source domain of either a user or a project.
 	// If UserDomainName and ProjectDomainName are not specified, then DomainName
 	// is used as a default choice.
 	// It can also be used be used to specify a domain-only scope.
-	DomainName string `yaml:"domain_name" json:"domain_name"`
+	DomainName string `yaml:"domain_name,omitempty" json:"domain_name"`
 
 	// DomainID is the unique ID of a domain which can be used to identify the
 	// source domain of eitehr a user or a project.
 	// If UserDomainID and ProjectDomainID are not specified, then DomainID is
 	// used as a default choice.
 	// It can also be used be used to specify a domain-only scope.
-	DomainID string `yaml:"domain_id" json:"domain_id"`
+	DomainID string `yaml:"domain_id,omitempty" json:"domain_id"`
 
 	// DefaultDomain is the domain ID to fall back on if no other domain has
 	// been specified and a domain is required for scope.
-	DefaultDomain string `yaml:"default_domain" json:"default_domain"`
+	DefaultDomain string `yaml:"default_domain,omitempty" json:"default_domain"`
 }
@@ -195,9 +195,9 @@ var FloridaAuthOpts = &gophercloud.AuthOptions{
 }
 
 var CaliforniaCloudYAML = clientconfig.Cloud{
-	Regions: []interface{}{
-		"SAN",
-		"LAX",
+	Regions: []clientconfig.Region{
+		{Name: "SAN"},
+		{Name: "LAX"},
 	},
 	AuthInfo: &clientconfig.AuthInfo{
 		AuthURL:           "https://ca.example.com:5000/v3",
@@ -501,3 +501,78 @@ var LegacyCloudYAML = clientconfig.Clouds{
 		"yukon":   YukonCloudYAML,
 	},
 }
+
+var MontrealCloudYAML = clientconfig.Cloud{
+	Profile:    "vexxhost",
+	AuthType:   "v3password",
+	RegionName: "ca-ymq-1",
+	Regions:    []clientconfig.Region{{Name: "ca-ymq-1"}, {Name: "sjc1"}},
+	AuthInfo: &clientconfig.AuthInfo{
+		AuthURL:     "https://auth.vexxhost.net/v3",
+		Username:    "jdoe",
+		Password:    "password",
+		ProjectName: "Some Project",
+	},
+	IdentityAPIVersion: "3",
+	APIVersions: map[string]string{
+		"dns": "1",
+	},
+	FloatingIPSource: "None",
+	Verify:           &iTrue,
+}
+
+var StockholmCloudYAML = clientconfig.Cloud{
+	Profile:    "citycloud",
+	RegionName: "Sto2",
+	Regions:    []clientconfig.Region{{Name: "Buf1"}, {Name: "La1"}, {Name: "Fra1"}, {Name: "Lon1"}, {Name: "Sto2"}, {Name: "Kna1"}},
+	AuthInfo: &clientconfig.AuthInfo{
+		AuthURL:           "https://Sto2.citycloud.com:5000/v3/",
+		Username:          "jdoe",
+		Password:          "password",
+		ProjectName:       "Some Project",
+		UserDomainName:    "default",
+		ProjectDomainName: "default",
+	},
+	IdentityAPIVersion:     "3",
+	BlockStorageAPIVersion: "1",
+	Verify:                 &iTrue,
+}
+
+var LisbonVendorProfile = clientconfig.VendorProfile{
+	Name: "lisbon_public",
+	Profile: clientconfig.Cloud{
+		RegionName: "LIS",
+		AuthInfo: &clientconfig.AuthInfo{
+			AuthURL: "https://lis.example.com:5000/v3",
+		},
+		IdentityAPIVersion: "3",
+	},
+}
+
+// LisbonVendorFile is LisbonVendorProfile in the format of openstacksdk
+// vendor files.
+const LisbonVendorFile = `
+{
+  "name": "lisbon_public",
+  "profile": {
+    "auth": {
+      "auth_url": "https://lis.example.com:5000/v3"
+    },
+    "region_name": "LIS",
+    "identity_api_version": "3"
+  }
+}
+`
+
+var LisbonCloudYAML = clientconfig.Cloud{
+	Profile:    "lisbon_public",
+	RegionName: "LIS",
+	AuthInfo: &clientconfig.AuthInfo{
+		AuthURL:     "https://lis.example.com:5000/v3",
+		Username:    "jdoe",
+		Password:    "password",
+		ProjectName: "Some Project",
+	},
+	IdentityAPIVersion: "3",
+	Verify:             &iTrue,
+}
@@ -0,0 +1,251 @@
+package testing
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gophercloud/utils/openstack/clientconfig"
+
+	th "github.com/gophercloud/gophercloud/testhelper"
+)
+
+const RegionsCloudsYAML = `
+clouds:
+  regions:
+    auth:
+      auth_url: "%[1]s"
+      username: "jdoe"
+      password: "password"
+      user_domain_name: "default"
+      project_id: "12345"
+    interface: "internal"
+    networks:
+      - name: "public"
+        routes_externally: true
+    regions:
+      - RegionOne
+      - name: RegionTwo
+        values:
+          interface: "public"
+          compute_endpoint_override: "https://two.example.com/compute/v2.1"
+          networks:
+            - name: "private"
+              nat_destination: true
+  authurls:
+    auth:
+      auth_url: "%[1]s"
+      username: "jdoe"
+      password: "password"
+      user_domain_name: "default"
+      project_id: "12345"
+    regions:
+      - name: RegionTwo
+        values:
+          compute_endpoint_override: "https://two.example.com/compute/v2.1"
+      - name: RegionOne
+      - name: RegionThree
+        values:
+          auth:
+            auth_url: "%[1]s/"
+          compute_endpoint_override: "https://three.example.com/compute/v2.1"
+  credentials:
+    auth:
+      auth_url: "%[1]s"
+      username: "jdoe"
+      password: "password"
+      user_domain_name: "default"
+      project_id: "12345"
+    regions:
+      - name: RegionOne
+      - name: RegionTwo
+        values:
+          auth:
+            username: "jsmith"
+            password: "secret"
+          compute_endpoint_override: "https://two.example.com/compute/v2.1"
+  noregions:
+    auth:
+      auth_url: "%[1]s"
+      username: "jdoe"
+      password: "password"
+      user_domain_name: "default"
+      project_id: "12345"
+  noname:
+    auth:
+      auth_url: "%[1]s"
+      username: "jdoe"
+      password: "password"
+      user_domain_name: "default"
+      project_id: "12345"
+    regions:
+      - values:
+          interface: "public"
+`
+
+func TestRegions(t *testing.T) {
+	os.Unsetenv("OS_CLOUD")
+	defer setupCloudsYAML(t, httptest.NewServer(identityHandler(t)), RegionsCloudsYAML)()
+
+	cloud, err := clientconfig.GetCloudFromYAML(&clientconfig.ClientOpts{Cloud: "regions"})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "", cloud.RegionName)
+	th.AssertEquals(t, "internal", cloud.Interface)
+
+	regions := cloud.Regions
+	th.AssertEquals(t, 2, len(regions))
+	th.AssertEquals(t, "RegionOne", regions[0].Name)
+	if regions[0].Values != nil {
+		t.Fatalf("unexpected values of region RegionOne: %v", regions[0].Values)
+	}
+	th.AssertEquals(t, "RegionTwo", regions[1].Name)
+	th.AssertEquals(t, "public", regions[1].Values.Interface)
+
+	regionCloud, err := cloud.ForRegion("RegionTwo")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "RegionTwo", regionCloud.RegionName)
+	th.AssertEquals(t, "public", regionCloud.Interface)
+	th.AssertEquals(t, "https://two.example.com/compute/v2.1", regionCloud.EndpointOverride("compute"))
+	th.AssertDeepEquals(t, []clientconfig.Network{{Name: "private", NATDestination: true}}, regionCloud.Networks)
+	th.AssertEquals(t, cloud.AuthInfo.AuthURL, regionCloud.AuthInfo.AuthURL)
+
+	_, err = cloud.ForRegion("RegionThree")
+	th.AssertEquals(t, "region RegionThree does not exist", err.Error())
+
+	// The values of the region are applied when the region is requested.
+	regionCloud, err = clientconfig.GetCloudFromYAML(&clientconfig.ClientOpts{Cloud: "regions", RegionName: "RegionTwo"})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "RegionTwo", regionCloud.RegionName)
+	th.AssertEquals(t, "public", regionCloud.Interface)
+
+	cloud, err = clientconfig.GetCloudFromYAML(&clientconfig.ClientOpts{Cloud: "noname"})
+	th.AssertNoErr(t, err)
+	_, err = cloud.ForRegion("RegionOne")
+	if err == nil {
+		t.Fatal("expected an error of a region without a name")
+	}
+}
+
+func TestNewServiceClients(t *testing.T) {
+	os.Unsetenv("OS_CLOUD")
+	defer setupCloudsYAML(t, httptest.NewServer(identityHandler(t)), RegionsCloudsYAML)()
+
+	clients, err := clientconfig.NewServiceClients("compute", &clientconfig.ClientOpts{Cloud: "regions"})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2, len(clients))
+	th.AssertEquals(t, "https://internal.example.com/compute/v2.1/", clients["RegionOne"].Endpoint)
+	th.AssertEquals(t, "https://two.example.com/compute/v2.1/", clients["RegionTwo"].Endpoint)
+
+	_, err = clientconfig.NewServiceClients("compute", &clientconfig.ClientOpts{Cloud: "noname"})
+	if err == nil {
+		t.Fatal("expected an error of a region without a name")
+	}
+}
+
+func TestNewServiceClientsAuthentication(t *testing.T) {
+	os.Unsetenv("OS_CLOUD")
+
+	var requests int
+	handler := identityHandler(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		handler.ServeHTTP(w, r)
+	}))
+	defer setupCloudsYAML(t, server, RegionsCloudsYAML)()
+
+	// Regions with the same auth URL share the authentication.
+	clients, err := clientconfig.NewServiceClients("compute", &clientconfig.ClientOpts{Cloud: "regions"})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2, len(clients))
+	th.AssertEquals(t, 1, requests)
+	if clients["RegionOne"].ProviderClient != clients["RegionTwo"].ProviderClient {
+		t.Fatal("expected regions to share the provider client")
+	}
+
+	// A region with a different auth URL is authenticated separately. The
+	// endpoint override of a region doesn't affect the following regions.
+	requests = 0
+	clients, err = clientconfig.NewServiceClients("compute", &clientconfig.ClientOpts{Cloud: "authurls"})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 3, len(clients))
+	th.AssertEquals(t, 2, requests)
+	th.AssertEquals(t, "https://public.example.com/compute/v2.1/", clients["RegionOne"].Endpoint)
+	th.AssertEquals(t, "https://two.example.com/compute/v2.1/", clients["RegionTwo"].Endpoint)
+	th.AssertEquals(t, "https://three.example.com/compute/v2.1/", clients["RegionThree"].Endpoint)
+	if clients["RegionOne"].ProviderClient == clients["RegionThree"].ProviderClient {
+		t.Fatal("expected a separate provider client for a different auth URL")
+	}
+}
+
+func TestNewServiceClientsCredentials(t *testing.T) {
+	os.Unsetenv("OS_CLOUD")
+
+	var usernames []string
+	handler := identityHandler(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Auth struct {
+				Identity struct {
+					Password struct {
+						User struct {
+							Name string `json:"name"`
+						} `json:"user"`
+					} `json:"password"`
+				} `json:"identity"`
+			} `json:"auth"`
+		}
+		th.AssertNoErr(t, json.NewDecoder(r.Body).Decode(&body))
+		usernames = append(usernames, body.Auth.Identity.Password.User.Name)
+		handler.ServeHTTP(w, r)
+	}))
+	defer setupCloudsYAML(t, server, RegionsCloudsYAML)()
+
+	// Regions with the same auth URL, but different credentials, are
+	// authenticated separately.
+	clients, err := clientconfig.NewServiceClients("compute", &clientconfig.ClientOpts{Cloud: "credentials"})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2, len(clients))
+	th.AssertDeepEquals(t, []string{"jdoe", "jsmith"}, usernames)
+	if clients["RegionOne"].ProviderClient == clients["RegionTwo"].ProviderClient {
+		t.Fatal("expected a separate provider client for different credentials")
+	}
+}
+
+func TestNewServiceClientsSingleRegion(t *testing.T) {
+	os.Unsetenv("OS_CLOUD")
+	defer setupCloudsYAML(t, httptest.NewServer(identityHandler(t)), ServiceClientCloudsYAML)()
+
+	clients, err := clientconfig.NewServiceClients("compute", &clientconfig.ClientOpts{Cloud: "default"})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(clients))
+	th.AssertEquals(t, "https://public.example.com/compute/v2.1/", clients["RegionOne"].Endpoint)
+
+	_, err = clientconfig.NewServiceClients("compute", &clientconfig.ClientOpts{Cloud: "invalid"})
+	th.AssertEquals(t, "no regions found for the cloud", err.Error())
+}
+
+func TestNewServiceClientsRegionName(t *testing.T) {
+	os.Unsetenv("OS_CLOUD")
+	os.Unsetenv("OS_REGION_NAME")
+	defer os.Unsetenv("OS_REGION_NAME")
+	defer setupCloudsYAML(t, httptest.NewServer(identityHandler(t)), RegionsCloudsYAML)()
+
+	_, err := clientconfig.NewServiceClients("compute", &clientconfig.ClientOpts{Cloud: "noregions"})
+	th.AssertEquals(t, "no regions found for the cloud", err.Error())
+
+	// Without the regions section, the region is taken from OS_REGION_NAME.
+	os.Setenv("OS_REGION_NAME", "RegionOne")
+	clients, err := clientconfig.NewServiceClients("compute", &clientconfig.ClientOpts{Cloud: "noregions"})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(clients))
+	th.AssertEquals(t, "https://public.example.com/compute/v2.1/", clients["RegionOne"].Endpoint)
+
+	// The region of the ClientOpts takes precedence.
+	os.Setenv("OS_REGION_NAME", "RegionTwo")
+	clients, err = clientconfig.NewServiceClients("compute", &clientconfig.ClientOpts{Cloud: "noregions", RegionName: "RegionOne"})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(clients))
+	th.AssertEquals(t, "https://public.example.com/compute/v2.1/", clients["RegionOne"].Endpoint)
+}
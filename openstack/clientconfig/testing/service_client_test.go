@@ -0,0 +1,226 @@
+package testing
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/utils/openstack/clientconfig"
+
+	th "github.com/gophercloud/gophercloud/testhelper"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// IdentityCatalog is a catalog of the fake Identity service.
+const IdentityCatalog = `
+[
+  {
+    "type": "compute",
+    "name": "nova",
+    "endpoints": [
+      {
+        "interface": "public",
+        "region": "RegionOne",
+        "url": "https://public.example.com/compute/v2.1"
+      },
+      {
+        "interface": "internal",
+        "region": "RegionOne",
+        "url": "https://internal.example.com/compute/v2.1"
+      }
+    ]
+  },
+  {
+    "type": "compute_legacy",
+    "name": "nova_legacy",
+    "endpoints": [
+      {
+        "interface": "public",
+        "region": "RegionOne",
+        "url": "https://public.example.com/compute/v2"
+      }
+    ]
+  },
+  {
+    "type": "volumev3",
+    "name": "cinderv3",
+    "endpoints": [
+      {
+        "interface": "public",
+        "region": "RegionOne",
+        "url": "https://public.example.com/volume/v3"
+      }
+    ]
+  }
+]
+`
+
+// identityHandler is a handler of a fake Identity service that issues
+// tokens with the IdentityCatalog.
+func identityHandler(t *testing.T) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		th.TestMethod(t, r, "POST")
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Subject-Token", "0123456789")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"token": {"expires_at": "2030-01-01T00:00:00.000000Z", "catalog": %s}}`, IdentityCatalog)
+	})
+}
+
+// setupCloudsYAML points the OS_CLIENT_CONFIG_FILE environment variable to a
+// clouds.yaml file with the provided content. The "%[1]s" verbs of the
+// content are replaced with the URL of the Identity service and the "%[2]s"
+// verbs with the directory of the clouds.yaml file.
+func setupCloudsYAML(t *testing.T, server *httptest.Server, content string) func() {
+	dir, err := ioutil.TempDir("", "clientconfig")
+	th.AssertNoErr(t, err)
+
+	filename := filepath.Join(dir, "clouds.yaml")
+	err = ioutil.WriteFile(filename, []byte(fmt.Sprintf(content, server.URL+"/v3", dir)), 0600)
+	th.AssertNoErr(t, err)
+
+	os.Setenv("OS_CLIENT_CONFIG_FILE", filename)
+
+	return func() {
+		os.Unsetenv("OS_CLIENT_CONFIG_FILE")
+		os.RemoveAll(dir)
+		server.Close()
+	}
+}
+
+const ServiceClientCloudsYAML = `
+clouds:
+  default:
+    auth:
+      auth_url: "%[1]s"
+      username: "jdoe"
+      password: "password"
+      user_domain_name: "default"
+      project_id: "12345"
+    region_name: "RegionOne"
+  internal:
+    auth:
+      auth_url: "%[1]s"
+      username: "jdoe"
+      password: "password"
+      user_domain_name: "default"
+      project_id: "12345"
+    region_name: "RegionOne"
+    interface: "internal"
+  override:
+    auth:
+      auth_url: "%[1]s"
+      username: "jdoe"
+      password: "password"
+      user_domain_name: "default"
+      project_id: "12345"
+    region_name: "RegionOne"
+    compute_endpoint_override: "https://override.example.com/compute/v2.1"
+    image_endpoint_override: "https://override.example.com/image"
+    compute_service_type: "compute_legacy"
+    block_storage_api_version: 3
+  legacy:
+    auth:
+      auth_url: "%[1]s"
+      username: "jdoe"
+      password: "password"
+      user_domain_name: "default"
+      project_id: "12345"
+    region_name: "RegionOne"
+    compute_service_type: "compute_legacy"
+  invalid:
+    auth:
+      auth_url: "%[1]s"
+      username: "jdoe"
+      password: "password"
+      user_domain_name: "default"
+      project_id: "12345"
+    interface: "private"
+`
+
+func TestNewServiceClient(t *testing.T) {
+	os.Unsetenv("OS_CLOUD")
+	defer setupCloudsYAML(t, httptest.NewServer(identityHandler(t)), ServiceClientCloudsYAML)()
+
+	expected := map[string]string{
+		"default":  "https://public.example.com/compute/v2.1/",
+		"internal": "https://internal.example.com/compute/v2.1/",
+		"override": "https://override.example.com/compute/v2.1/",
+		"legacy":   "https://public.example.com/compute/v2/",
+	}
+
+	for cloud, endpoint := range expected {
+		client, err := clientconfig.NewServiceClient("compute", &clientconfig.ClientOpts{Cloud: cloud})
+		th.AssertNoErr(t, err)
+		th.AssertEquals(t, endpoint, client.Endpoint)
+	}
+
+	client, err := clientconfig.NewServiceClient("volume", &clientconfig.ClientOpts{Cloud: "override"})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "https://public.example.com/volume/v3/", client.Endpoint)
+
+	// The endpoint override doesn't change how the ProviderClient locates
+	// endpoints of other services.
+	client, err = clientconfig.NewServiceClient("image", &clientconfig.ClientOpts{Cloud: "override"})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "https://override.example.com/image/", client.Endpoint)
+	th.AssertEquals(t, "https://override.example.com/image/v2/", client.ResourceBase)
+	th.AssertEquals(t, "image", client.Type)
+
+	endpoint, err := client.ProviderClient.EndpointLocator(gophercloud.EndpointOpts{
+		Type:         "compute",
+		Region:       "RegionOne",
+		Availability: gophercloud.AvailabilityPublic,
+	})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "https://public.example.com/compute/v2.1/", endpoint)
+
+	_, err = clientconfig.NewServiceClient("compute", &clientconfig.ClientOpts{Cloud: "invalid"})
+	th.AssertEquals(t, "invalid interface: private", err.Error())
+}
+
+func TestGetCloudFromYAMLServiceOptions(t *testing.T) {
+	os.Unsetenv("OS_CLOUD")
+	defer setupCloudsYAML(t, httptest.NewServer(identityHandler(t)), ServiceClientCloudsYAML)()
+
+	cloud, err := clientconfig.GetCloudFromYAML(&clientconfig.ClientOpts{Cloud: "override"})
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, "https://override.example.com/compute/v2.1", cloud.EndpointOverride("compute"))
+	th.AssertEquals(t, "compute_legacy", cloud.ServiceType("compute"))
+	th.AssertEquals(t, "3", cloud.APIVersion("volume"))
+	th.AssertEquals(t, "3", cloud.APIVersion("block-storage"))
+	th.AssertEquals(t, "", cloud.EndpointOverride("network"))
+}
+
+func TestCloudServiceOptionsYAML(t *testing.T) {
+	cloud := clientconfig.Cloud{
+		RegionName: "RegionOne",
+		APIVersions: map[string]string{
+			"image": "2",
+		},
+		EndpointOverrides: map[string]string{
+			"object_store": "https://swift.example.com/v1/AUTH_12345",
+		},
+		ServiceTypes: map[string]string{
+			"database": "rax:database",
+		},
+	}
+
+	b, err := yaml.Marshal(cloud)
+	th.AssertNoErr(t, err)
+
+	var actual clientconfig.Cloud
+	err = yaml.Unmarshal(b, &actual)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, cloud.RegionName, actual.RegionName)
+	th.AssertDeepEquals(t, cloud.APIVersions, actual.APIVersions)
+	th.AssertDeepEquals(t, cloud.EndpointOverrides, actual.EndpointOverrides)
+	th.AssertDeepEquals(t, cloud.ServiceTypes, actual.ServiceTypes)
+	th.AssertEquals(t, "https://swift.example.com/v1/AUTH_12345", actual.EndpointOverride("object-store"))
+}
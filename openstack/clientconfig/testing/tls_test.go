@@ -0,0 +1,164 @@
+package testing
+
+import (
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gophercloud/utils/openstack/clientconfig"
+
+	th "github.com/gophercloud/gophercloud/testhelper"
+)
+
+const TLSCloudsYAML = `
+clouds:
+  default:
+    auth:
+      auth_url: "%[1]s"
+      username: "jdoe"
+      password: "password"
+      user_domain_name: "default"
+      project_id: "12345"
+    region_name: "RegionOne"
+  cacert:
+    auth:
+      auth_url: "%[1]s"
+      username: "jdoe"
+      password: "password"
+      user_domain_name: "default"
+      project_id: "12345"
+    region_name: "RegionOne"
+    cacert: "%[2]s/ca.pem"
+  insecure:
+    auth:
+      auth_url: "%[1]s"
+      username: "jdoe"
+      password: "password"
+      user_domain_name: "default"
+      project_id: "12345"
+    region_name: "RegionOne"
+    verify: false
+  missing:
+    auth:
+      auth_url: "%[1]s"
+      username: "jdoe"
+      password: "password"
+      user_domain_name: "default"
+      project_id: "12345"
+    region_name: "RegionOne"
+    cacert: "%[2]s/missing.pem"
+`
+
+// setupTLSCloudsYAML starts a fake Identity service with TLS and writes its
+// certificate to the ca.pem file next to the clouds.yaml file. The path of
+// the certificate is returned along with the teardown function.
+func setupTLSCloudsYAML(t *testing.T) (string, func()) {
+	server := httptest.NewTLSServer(identityHandler(t))
+	teardown := setupCloudsYAML(t, server, TLSCloudsYAML)
+
+	caCertFile := filepath.Join(filepath.Dir(os.Getenv("OS_CLIENT_CONFIG_FILE")), "ca.pem")
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	err := ioutil.WriteFile(caCertFile, caCert, 0600)
+	th.AssertNoErr(t, err)
+
+	return caCertFile, teardown
+}
+
+func TestAuthenticatedClientTLS(t *testing.T) {
+	os.Unsetenv("OS_CLOUD")
+	_, teardown := setupTLSCloudsYAML(t)
+	defer teardown()
+
+	_, err := clientconfig.AuthenticatedClient(&clientconfig.ClientOpts{Cloud: "default"})
+	if err == nil {
+		t.Fatal("expected an error of an untrusted certificate")
+	}
+
+	for _, cloud := range []string{"cacert", "insecure"} {
+		_, err := clientconfig.AuthenticatedClient(&clientconfig.ClientOpts{Cloud: cloud})
+		th.AssertNoErr(t, err)
+	}
+
+	client, err := clientconfig.NewServiceClient("compute", &clientconfig.ClientOpts{Cloud: "cacert"})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "https://public.example.com/compute/v2.1/", client.Endpoint)
+
+	_, err = clientconfig.HTTPClient(&clientconfig.ClientOpts{Cloud: "missing"})
+	if err == nil {
+		t.Fatal("expected an error of a missing CA certificate")
+	}
+}
+
+func TestAuthenticatedClientTLSEnv(t *testing.T) {
+	os.Unsetenv("OS_CLOUD")
+	caCertFile, teardown := setupTLSCloudsYAML(t)
+	defer teardown()
+
+	// Authenticate without clouds.yaml to let the environment variables
+	// take effect.
+	cloud, err := clientconfig.GetCloudFromYAML(&clientconfig.ClientOpts{Cloud: "default"})
+	th.AssertNoErr(t, err)
+
+	opts := &clientconfig.ClientOpts{AuthInfo: cloud.AuthInfo}
+
+	_, err = clientconfig.AuthenticatedClient(opts)
+	if err == nil {
+		t.Fatal("expected an error of an untrusted certificate")
+	}
+
+	os.Setenv("OS_CACERT", caCertFile)
+	_, err = clientconfig.AuthenticatedClient(opts)
+	os.Unsetenv("OS_CACERT")
+	th.AssertNoErr(t, err)
+
+	os.Setenv("OS_INSECURE", "true")
+	_, err = clientconfig.AuthenticatedClient(opts)
+	os.Unsetenv("OS_INSECURE")
+	th.AssertNoErr(t, err)
+
+	os.Setenv("OS_INSECURE", "maybe")
+	_, err = clientconfig.AuthenticatedClient(opts)
+	os.Unsetenv("OS_INSECURE")
+	th.AssertEquals(t, "invalid value of OS_INSECURE: maybe", err.Error())
+
+	os.Setenv("OS_KEY", caCertFile)
+	_, err = clientconfig.HTTPClient(opts)
+	os.Unsetenv("OS_KEY")
+	th.AssertEquals(t, "a client key was specified without a client certificate", err.Error())
+}
+
+func TestHTTPClientTransport(t *testing.T) {
+	os.Unsetenv("OS_CLOUD")
+	_, teardown := setupTLSCloudsYAML(t)
+	defer teardown()
+
+	defaultTransport := http.DefaultTransport.(*http.Transport)
+	defaultTLSConfig := defaultTransport.TLSClientConfig
+
+	client, err := clientconfig.HTTPClient(&clientconfig.ClientOpts{Cloud: "cacert"})
+	th.AssertNoErr(t, err)
+
+	transport, ok := client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("expected an *http.Transport, got %T", client.Transport)
+	}
+	if transport.TLSClientConfig == nil || transport.TLSClientConfig.RootCAs == nil {
+		t.Fatal("expected a TLS configuration with the CA certificate")
+	}
+	if transport.Proxy == nil {
+		t.Fatal("expected the proxy settings of the default transport")
+	}
+
+	// Timeouts of the default transport are kept, the default transport
+	// itself isn't modified.
+	th.AssertEquals(t, defaultTransport.TLSHandshakeTimeout, transport.TLSHandshakeTimeout)
+	th.AssertEquals(t, defaultTransport.IdleConnTimeout, transport.IdleConnTimeout)
+	th.AssertEquals(t, defaultTransport.MaxIdleConns, transport.MaxIdleConns)
+	if defaultTransport.TLSClientConfig != defaultTLSConfig {
+		t.Fatal("expected the default transport to stay unchanged")
+	}
+}
@@ -0,0 +1,99 @@
+package testing
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gophercloud/utils/openstack/clientconfig"
+
+	th "github.com/gophercloud/gophercloud/testhelper"
+)
+
+func TestGetCloudFromYAMLWithVendorProfile(t *testing.T) {
+	allClientOpts := map[string]*clientconfig.ClientOpts{
+		"montreal":  &clientconfig.ClientOpts{Cloud: "montreal"},
+		"stockholm": &clientconfig.ClientOpts{Cloud: "stockholm"},
+	}
+
+	expectedClouds := map[string]*clientconfig.Cloud{
+		"montreal":  &MontrealCloudYAML,
+		"stockholm": &StockholmCloudYAML,
+	}
+
+	for cloud, clientOpts := range allClientOpts {
+		actual, err := clientconfig.GetCloudFromYAML(clientOpts)
+		th.AssertNoErr(t, err)
+		th.AssertDeepEquals(t, expectedClouds[cloud], actual)
+	}
+}
+
+func TestGetCloudFromYAMLWithRegisteredVendorProfile(t *testing.T) {
+	clientOpts := &clientconfig.ClientOpts{
+		Cloud: "lisbon",
+	}
+
+	_, err := clientconfig.GetCloudFromYAML(clientOpts)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	th.AssertEquals(t, "cloud lisbon_public does not exist in clouds-public.yaml or vendor profiles", err.Error())
+
+	var vendorProfile clientconfig.VendorProfile
+	err = json.Unmarshal([]byte(LisbonVendorFile), &vendorProfile)
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, LisbonVendorProfile, vendorProfile)
+
+	err = clientconfig.RegisterVendorProfile(vendorProfile)
+	th.AssertNoErr(t, err)
+	defer clientconfig.UnregisterVendorProfile(vendorProfile.Name)
+
+	actual, err := clientconfig.GetCloudFromYAML(clientOpts)
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, &LisbonCloudYAML, actual)
+
+	// The registered profile isn't changed by the merge.
+	profile, err := clientconfig.LoadVendorProfile("lisbon_public")
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, &LisbonVendorProfile.Profile, profile)
+}
+
+func TestLoadVendorProfile(t *testing.T) {
+	profile, err := clientconfig.LoadVendorProfile("ovh")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "https://auth.cloud.ovh.net/", profile.AuthInfo.AuthURL)
+	th.AssertEquals(t, "3", profile.IdentityAPIVersion)
+	th.AssertEquals(t, 8, len(profile.Regions))
+
+	_, err = clientconfig.LoadVendorProfile("atlantis_public")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	th.AssertEquals(t, "vendor profile atlantis_public does not exist", err.Error())
+
+	err = clientconfig.RegisterVendorProfile(clientconfig.VendorProfile{})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	th.AssertEquals(t, "vendor profile name is empty", err.Error())
+}
+
+func TestUnregisterVendorProfile(t *testing.T) {
+	profile := clientconfig.VendorProfile{
+		Name:    "ovh",
+		Profile: clientconfig.Cloud{RegionName: "GRA1"},
+	}
+
+	err := clientconfig.RegisterVendorProfile(profile)
+	th.AssertNoErr(t, err)
+
+	actual, err := clientconfig.LoadVendorProfile("ovh")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "GRA1", actual.RegionName)
+
+	// The embedded profile is used again after unregistering.
+	clientconfig.UnregisterVendorProfile("ovh")
+
+	actual, err = clientconfig.LoadVendorProfile("ovh")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "https://auth.cloud.ovh.net/", actual.AuthInfo.AuthURL)
+}
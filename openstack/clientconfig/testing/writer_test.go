@@ -0,0 +1,329 @@
+package testing
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gophercloud/utils/openstack/clientconfig"
+
+	th "github.com/gophercloud/gophercloud/testhelper"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const EditableCloudsYAML = `cache:
+  expiration_time: 3600
+clouds:
+  hawaii:
+    auth:
+      auth_url: https://hi.example.com:5000/v3
+      username: jdoe
+      password: password
+      project_name: Some Project
+      domain_name: default
+      system_scope: all
+    region_name: HNL
+    image_format: raw
+    compute_api_version: "2.1"
+  florida:
+    auth:
+      auth_url: https://fl.example.com:5000/v3
+      token: "12345"
+    region_name: MIA
+`
+
+const ExpectedCloudsYAML = `cache:
+  expiration_time: 3600
+clouds:
+  hawaii:
+    auth:
+      auth_url: https://hi.example.com:5000/v3
+      username: jdoe
+      project_name: Other Project
+      domain_name: default
+      system_scope: all
+    region_name: OGG
+    image_format: raw
+  oregon:
+    auth:
+      auth_url: https://or.example.com:5000/v3
+      username: jdoe
+      user_domain_name: default
+    region_name: PDX
+`
+
+const ExistingSecureYAML = `clouds:
+  florida:
+    auth:
+      token: "12345"
+  utah:
+    auth:
+      password: secret
+`
+
+const ExpectedSecureYAML = `clouds:
+  utah:
+    auth:
+      password: secret
+  hawaii:
+    auth:
+      password: password
+  oregon:
+    auth:
+      application_credential_secret: secret
+`
+
+func TestCloudsFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clientconfig")
+	th.AssertNoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "clouds.yaml")
+	secureFilename := filepath.Join(dir, "secure.yaml")
+
+	err = ioutil.WriteFile(filename, []byte(EditableCloudsYAML), 0644)
+	th.AssertNoErr(t, err)
+	err = ioutil.WriteFile(secureFilename, []byte(ExistingSecureYAML), 0644)
+	th.AssertNoErr(t, err)
+
+	f, err := clientconfig.ReadCloudsFile(filename)
+	th.AssertNoErr(t, err)
+
+	hawaii, err := f.Cloud("hawaii")
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "HNL", hawaii.RegionName)
+	th.AssertEquals(t, "2.1", hawaii.APIVersion("compute"))
+
+	// Update modeled keys and keep the others.
+	hawaii.RegionName = "OGG"
+	hawaii.AuthInfo.ProjectName = "Other Project"
+	hawaii.APIVersions = nil
+	err = f.SetCloud("hawaii", *hawaii)
+	th.AssertNoErr(t, err)
+
+	err = f.SetCloud("oregon", clientconfig.Cloud{
+		AuthInfo: &clientconfig.AuthInfo{
+			AuthURL:                     "https://or.example.com:5000/v3",
+			Username:                    "jdoe",
+			UserDomainName:              "default",
+			ApplicationCredentialSecret: "secret",
+		},
+		RegionName: "PDX",
+	})
+	th.AssertNoErr(t, err)
+
+	th.AssertEquals(t, true, f.RemoveCloud("florida"))
+	th.AssertEquals(t, false, f.RemoveCloud("florida"))
+
+	clouds, err := f.Clouds()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2, len(clouds.Clouds))
+	th.AssertEquals(t, "password", clouds.Clouds["hawaii"].AuthInfo.Password)
+
+	err = f.WriteWithSecureFile(filename, secureFilename)
+	th.AssertNoErr(t, err)
+
+	for file, expected := range map[string]string{
+		filename:       ExpectedCloudsYAML,
+		secureFilename: ExpectedSecureYAML,
+	} {
+		content, err := ioutil.ReadFile(file)
+		th.AssertNoErr(t, err)
+		th.AssertEquals(t, expected, string(content))
+
+		info, err := os.Stat(file)
+		th.AssertNoErr(t, err)
+		th.AssertEquals(t, os.FileMode(0600), info.Mode().Perm())
+	}
+
+	// The file that was written isn't modified.
+	clouds, err = f.Clouds()
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, "password", clouds.Clouds["hawaii"].AuthInfo.Password)
+}
+
+func TestCloudsFileWriteFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clientconfig")
+	th.AssertNoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	// A directory can't be replaced by the written file.
+	filename := filepath.Join(dir, "clouds.yaml")
+	err = os.Mkdir(filename, 0700)
+	th.AssertNoErr(t, err)
+
+	f := clientconfig.NewCloudsFile()
+	err = f.SetCloud("hawaii", HawaiiCloudYAML)
+	th.AssertNoErr(t, err)
+
+	err = f.Write(filename)
+	if err == nil {
+		t.Fatal("expected an error of writing over a directory")
+	}
+
+	// The temporary file is removed.
+	files, err := ioutil.ReadDir(dir)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 1, len(files))
+	th.AssertEquals(t, "clouds.yaml", files[0].Name())
+}
+
+func TestCloudsFileWriteSymlink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clientconfig")
+	th.AssertNoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	target := filepath.Join(dir, "target.yaml")
+	err = ioutil.WriteFile(target, []byte(EditableCloudsYAML), 0644)
+	th.AssertNoErr(t, err)
+
+	filename := filepath.Join(dir, "clouds.yaml")
+	err = os.Symlink(target, filename)
+	th.AssertNoErr(t, err)
+
+	f, err := clientconfig.ReadCloudsFile(filename)
+	th.AssertNoErr(t, err)
+	th.AssertNoErr(t, f.Write(filename))
+
+	// The symlink is kept and the target is replaced.
+	info, err := os.Lstat(filename)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, os.ModeSymlink, info.Mode()&os.ModeSymlink)
+
+	info, err = os.Stat(target)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, os.FileMode(0600), info.Mode().Perm())
+
+	files, err := ioutil.ReadDir(dir)
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, 2, len(files))
+}
+
+func TestCloudsFileNew(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clientconfig")
+	th.AssertNoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "clouds.yaml")
+
+	f, err := clientconfig.ReadCloudsFile(filename)
+	th.AssertNoErr(t, err)
+
+	err = f.SetCloud("", clientconfig.Cloud{})
+	th.AssertEquals(t, "cloud name is empty", err.Error())
+
+	_, err = f.Cloud("hawaii")
+	th.AssertEquals(t, "cloud hawaii does not exist", err.Error())
+
+	err = f.SetCloud("hawaii", HawaiiCloudYAML)
+	th.AssertNoErr(t, err)
+
+	err = f.Write(filename)
+	th.AssertNoErr(t, err)
+
+	f, err = clientconfig.ReadCloudsFile(filename)
+	th.AssertNoErr(t, err)
+
+	hawaii, err := f.Cloud("hawaii")
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, HawaiiCloudYAML, *hawaii)
+}
+
+func TestMarshalCloudsYAML(t *testing.T) {
+	clouds := clientconfig.Clouds{
+		Clouds: map[string]clientconfig.Cloud{
+			"hawaii":  HawaiiCloudYAML,
+			"florida": FloridaCloudYAML,
+		},
+	}
+
+	b, err := clientconfig.MarshalCloudsYAML(clouds)
+	th.AssertNoErr(t, err)
+
+	var actual clientconfig.Clouds
+	err = yaml.Unmarshal(b, &actual)
+	th.AssertNoErr(t, err)
+	th.AssertDeepEquals(t, clouds, actual)
+}
+
+const SplitCloudsYAML = `clouds:
+  old:
+    auth:
+      auth_url: https://old.example.com:5000/v3
+      username: jdoe
+    region_name: RegionOne
+  gone:
+    auth:
+      auth_url: https://gone.example.com:5000/v3
+      username: jdoe
+`
+
+const SplitSecureYAML = `clouds:
+  old:
+    auth:
+      password: oldpassword
+  gone:
+    auth:
+      password: gonepassword
+`
+
+const ExpectedSplitCloudsYAML = `clouds:
+  old:
+    auth:
+      auth_url: https://old.example.com:5000/v3
+      username: jdoe
+    region_name: RegionOne
+  new:
+    auth:
+      auth_url: https://new.example.com:5000/v3
+      username: jdoe
+`
+
+const ExpectedSplitSecureYAML = `clouds:
+  old:
+    auth:
+      password: oldpassword
+  new:
+    auth:
+      password: newpassword
+`
+
+func TestCloudsFileWithExistingSecureFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "clientconfig")
+	th.AssertNoErr(t, err)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "clouds.yaml")
+	secureFilename := filepath.Join(dir, "secure.yaml")
+
+	err = ioutil.WriteFile(filename, []byte(SplitCloudsYAML), 0600)
+	th.AssertNoErr(t, err)
+	err = ioutil.WriteFile(secureFilename, []byte(SplitSecureYAML), 0600)
+	th.AssertNoErr(t, err)
+
+	f, err := clientconfig.ReadCloudsFile(filename)
+	th.AssertNoErr(t, err)
+
+	err = f.SetCloud("new", clientconfig.Cloud{
+		AuthInfo: &clientconfig.AuthInfo{
+			AuthURL:  "https://new.example.com:5000/v3",
+			Username: "jdoe",
+			Password: "newpassword",
+		},
+	})
+	th.AssertNoErr(t, err)
+	th.AssertEquals(t, true, f.RemoveCloud("gone"))
+
+	err = f.WriteWithSecureFile(filename, secureFilename)
+	th.AssertNoErr(t, err)
+
+	for file, expected := range map[string]string{
+		filename:       ExpectedSplitCloudsYAML,
+		secureFilename: ExpectedSplitSecureYAML,
+	} {
+		content, err := ioutil.ReadFile(file)
+		th.AssertNoErr(t, err)
+		th.AssertEquals(t, expected, string(content))
+	}
+}
@@ -0,0 +1,17 @@
+//go:build go1.13
+// +build go1.13
+
+package clientconfig
+
+import (
+	"crypto/tls"
+	"net/http"
+)
+
+// newTransport returns a copy of the default transport with the TLS
+// configuration. HTTP/2 stays enabled since the default transport forces it.
+func newTransport(config *tls.Config) *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = config
+	return transport
+}
@@ -0,0 +1,25 @@
+//go:build !go1.13
+// +build !go1.13
+
+package clientconfig
+
+import (
+	"crypto/tls"
+	"net/http"
+)
+
+// newTransport returns a transport with the settings of the default
+// transport and the TLS configuration. Transports can't be cloned before
+// Go 1.13, and HTTP/2 isn't configured for a custom TLS configuration.
+func newTransport(config *tls.Config) *http.Transport {
+	defaultTransport := http.DefaultTransport.(*http.Transport)
+	return &http.Transport{
+		Proxy:                 defaultTransport.Proxy,
+		DialContext:           defaultTransport.DialContext,
+		MaxIdleConns:          defaultTransport.MaxIdleConns,
+		IdleConnTimeout:       defaultTransport.IdleConnTimeout,
+		TLSHandshakeTimeout:   defaultTransport.TLSHandshakeTimeout,
+		ExpectContinueTimeout: defaultTransport.ExpectContinueTimeout,
+		TLSClientConfig:       config,
+	}
+}
@@ -143,7 +143,7 @@ func findAndReadYAML(yamlFile string) ([]byte, error) {
 		return ioutil.ReadFile("/etc/openstack/" + yamlFile)
 	}
 
-	return nil, fmt.Errorf("no " + yamlFile + " file found")
+	return nil, fmt.Errorf("no %s file found", yamlFile)
 }
 
 // fileExists checks for the existence of a file at a given location.
@@ -0,0 +1,277 @@
+package clientconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// VendorProfile represents a vendor file of the openstacksdk library.
+// It can be decoded from a JSON or YAML vendor file and registered with
+// the RegisterVendorProfile function.
+type VendorProfile struct {
+	// Name is a name of the profile that is referenced by the "profile"
+	// or "cloud" keys of a clouds.yaml entry.
+	Name string `yaml:"name" json:"name"`
+
+	// Profile contains settings of the public cloud.
+	Profile Cloud `yaml:"profile" json:"profile"`
+}
+
+var (
+	vendorProfilesMu sync.RWMutex
+
+	// registeredVendorProfiles contains JSON representations of profiles
+	// that were registered at runtime.
+	registeredVendorProfiles = make(map[string][]byte)
+)
+
+// RegisterVendorProfile makes a vendor profile available for clouds.yaml
+// entries that reference it with the "profile" key. A registered profile
+// replaces an embedded profile with the same name. Profiles defined in
+// clouds-public.yaml still take precedence over vendor profiles.
+func RegisterVendorProfile(profile VendorProfile) error {
+	if profile.Name == "" {
+		return fmt.Errorf("vendor profile name is empty")
+	}
+
+	b, err := json.Marshal(profile.Profile)
+	if err != nil {
+		return fmt.Errorf("unable to encode vendor profile %s: %s", profile.Name, err)
+	}
+
+	vendorProfilesMu.Lock()
+	defer vendorProfilesMu.Unlock()
+	registeredVendorProfiles[profile.Name] = b
+
+	return nil
+}
+
+// UnregisterVendorProfile removes a vendor profile registered with
+// RegisterVendorProfile. Embedded profiles can't be removed.
+func UnregisterVendorProfile(name string) {
+	vendorProfilesMu.Lock()
+	defer vendorProfilesMu.Unlock()
+	delete(registeredVendorProfiles, name)
+}
+
+// LoadVendorProfile returns a copy of the registered or embedded vendor
+// profile.
+func LoadVendorProfile(name string) (*Cloud, error) {
+	vendorProfilesMu.RLock()
+	b, ok := registeredVendorProfiles[name]
+	vendorProfilesMu.RUnlock()
+
+	if !ok {
+		var s string
+		if s, ok = embeddedVendorProfiles[name]; !ok {
+			return nil, fmt.Errorf("vendor profile %s does not exist", name)
+		}
+		b = []byte(s)
+	}
+
+	var profile Cloud
+	if err := json.Unmarshal(b, &profile); err != nil {
+		return nil, fmt.Errorf("unable to decode vendor profile %s: %s", name, err)
+	}
+
+	return &profile, nil
+}
+
+// embeddedVendorProfiles contains profiles of public clouds from the
+// openstacksdk vendor files:
+// https://github.com/openstack/openstacksdk/tree/master/openstack/config/vendors
+var embeddedVendorProfiles = map[string]string{
+	"auro": `{
+		"auth": {
+			"auth_url": "https://api.van1.auro.io:5000/v2.0"
+		},
+		"identity_api_version": "2",
+		"region_name": "van1",
+		"requires_floating_ip": true
+	}`,
+	"betacloud": `{
+		"auth": {
+			"auth_url": "https://api-1.betacloud.de:5000"
+		},
+		"regions": [
+			"betacloud-1"
+		],
+		"identity_api_version": "3",
+		"image_format": "raw",
+		"block_storage_api_version": "3"
+	}`,
+	"bluebox": `{
+		"block_storage_api_version": "1",
+		"region_name": "RegionOne"
+	}`,
+	"catalyst": `{
+		"auth": {
+			"auth_url": "https://api.cloud.catalyst.net.nz:5000/v2.0"
+		},
+		"regions": [
+			"nz-por-1",
+			"nz_wlg_2"
+		],
+		"image_api_version": "1",
+		"block_storage_api_version": "1",
+		"image_format": "raw"
+	}`,
+	"citycloud": `{
+		"auth": {
+			"auth_url": "https://{region_name}.citycloud.com:5000/v3/"
+		},
+		"regions": [
+			"Buf1",
+			"La1",
+			"Fra1",
+			"Lon1",
+			"Sto2",
+			"Kna1"
+		],
+		"requires_floating_ip": true,
+		"block_storage_api_version": "1",
+		"identity_api_version": "3"
+	}`,
+	"conoha": `{
+		"auth": {
+			"auth_url": "https://identity.{region_name}.conoha.io"
+		},
+		"regions": [
+			"sin1",
+			"sjc1",
+			"tyo1"
+		]
+	}`,
+	"dreamcompute": `{
+		"auth": {
+			"auth_url": "https://iad2.dream.io:5000"
+		},
+		"identity_api_version": "3",
+		"region_name": "RegionOne",
+		"image_format": "raw"
+	}`,
+	"elastx": `{
+		"auth": {
+			"auth_url": "https://ops.elastx.cloud:5000/v3"
+		},
+		"identity_api_version": "3",
+		"region_name": "se-sto"
+	}`,
+	"entercloudsuite": `{
+		"auth": {
+			"auth_url": "https://api.entercloudsuite.com/"
+		},
+		"identity_api_version": "3",
+		"image_api_version": "1",
+		"block_storage_api_version": "1",
+		"regions": [
+			"it-mil1",
+			"nl-ams1",
+			"de-fra1"
+		]
+	}`,
+	"fuga": `{
+		"auth": {
+			"auth_url": "https://identity.api.fuga.io:5000",
+			"user_domain_name": "Default",
+			"project_domain_name": "Default"
+		},
+		"regions": [
+			"cystack"
+		],
+		"identity_api_version": "3",
+		"block_storage_api_version": "3"
+	}`,
+	"ibmcloud": `{
+		"auth": {
+			"auth_url": "https://identity.open.softlayer.com"
+		},
+		"block_storage_api_version": "2",
+		"identity_api_version": "3",
+		"regions": [
+			"london"
+		]
+	}`,
+	"internap": `{
+		"auth": {
+			"auth_url": "https://identity.api.cloud.iweb.com"
+		},
+		"regions": [
+			"ams01",
+			"da01",
+			"nyj01",
+			"sin01",
+			"sjc01"
+		],
+		"identity_api_version": "3",
+		"floating_ip_source": "None"
+	}`,
+	"ovh": `{
+		"auth": {
+			"auth_url": "https://auth.cloud.ovh.net/"
+		},
+		"regions": [
+			"BHS1",
+			"BHS3",
+			"DE1",
+			"GRA1",
+			"GRA5",
+			"SBG5",
+			"UK1",
+			"WAW1"
+		],
+		"identity_api_version": "3",
+		"floating_ip_source": "None"
+	}`,
+	"rackspace": `{
+		"auth": {
+			"auth_url": "https://identity.api.rackspacecloud.com/v2.0/"
+		},
+		"database_service_type": "rax:database",
+		"compute_service_name": "cloudServersOpenStack",
+		"image_api_use_tasks": true,
+		"image_format": "vhd",
+		"floating_ip_source": "None",
+		"secgroup_source": "None",
+		"requires_floating_ip": false,
+		"volume_api_version": "1"
+	}`,
+	"switchengines": `{
+		"auth": {
+			"auth_url": "https://keystone.cloud.switch.ch:5000/v2.0"
+		},
+		"regions": [
+			"LS",
+			"ZH"
+		],
+		"block_storage_api_version": "1",
+		"image_api_use_tasks": true,
+		"image_format": "raw"
+	}`,
+	"vexxhost": `{
+		"auth_type": "v3password",
+		"auth": {
+			"auth_url": "https://auth.vexxhost.net/v3"
+		},
+		"regions": [
+			"ca-ymq-1",
+			"sjc1"
+		],
+		"dns_api_version": "1",
+		"identity_api_version": "3",
+		"image_format": "raw",
+		"floating_ip_source": "None",
+		"requires_floating_ip": false
+	}`,
+	"zetta": `{
+		"auth": {
+			"auth_url": "https://identity.api.zetta.io/v3"
+		},
+		"regions": [
+			"no-osl1"
+		],
+		"identity_api_version": "3",
+		"dns_api_version": "2"
+	}`,
+}
@@ -0,0 +1,406 @@
+package clientconfig
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// secretKeys are keys of the auth section of a cloud entry that are written
+// to secure.yaml.
+var secretKeys = []string{
+	"password",
+	"token",
+	"application_credential_secret",
+}
+
+// MarshalCloudsYAML encodes the clouds as a clouds.yaml document.
+func MarshalCloudsYAML(clouds Clouds) ([]byte, error) {
+	return yaml.Marshal(clouds)
+}
+
+// CloudsFile represents a clouds.yaml or secure.yaml document that can be
+// edited and written back. Keys of the document and of its cloud entries
+// that aren't modeled by the Cloud struct are preserved.
+type CloudsFile struct {
+	doc yaml.MapSlice
+
+	// removed contains names of clouds removed from the document, their
+	// secrets are removed from secure.yaml.
+	removed map[string]bool
+}
+
+// NewCloudsFile returns an empty CloudsFile.
+func NewCloudsFile() *CloudsFile {
+	return new(CloudsFile)
+}
+
+// ReadCloudsFile reads a clouds.yaml or secure.yaml file. If the file does
+// not exist, an empty CloudsFile is returned.
+func ReadCloudsFile(filename string) (*CloudsFile, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return NewCloudsFile(), nil
+		}
+		return nil, err
+	}
+
+	f := new(CloudsFile)
+	if err := yaml.Unmarshal(content, &f.doc); err != nil {
+		return nil, fmt.Errorf("unable to decode %s: %s", filename, err)
+	}
+
+	return f, nil
+}
+
+// Clouds returns the cloud entries of the document.
+func (f *CloudsFile) Clouds() (*Clouds, error) {
+	b, err := yaml.Marshal(f.doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var clouds Clouds
+	if err := yaml.Unmarshal(b, &clouds); err != nil {
+		return nil, err
+	}
+
+	return &clouds, nil
+}
+
+// Cloud returns a cloud entry of the document.
+func (f *CloudsFile) Cloud(name string) (*Cloud, error) {
+	clouds, err := f.Clouds()
+	if err != nil {
+		return nil, err
+	}
+
+	cloud, ok := clouds.Clouds[name]
+	if !ok {
+		return nil, fmt.Errorf("cloud %s does not exist", name)
+	}
+
+	return &cloud, nil
+}
+
+// SetCloud adds a cloud entry to the document or updates an existing one.
+// The keys of an existing entry that are modeled by the Cloud struct are
+// replaced by the settings of the cloud, other keys are kept.
+func (f *CloudsFile) SetCloud(name string, cloud Cloud) error {
+	if name == "" {
+		return fmt.Errorf("cloud name is empty")
+	}
+
+	b, err := yaml.Marshal(cloud)
+	if err != nil {
+		return fmt.Errorf("unable to encode cloud %s: %s", name, err)
+	}
+
+	var entry yaml.MapSlice
+	if err := yaml.Unmarshal(b, &entry); err != nil {
+		return fmt.Errorf("unable to encode cloud %s: %s", name, err)
+	}
+
+	clouds := f.clouds()
+	if existing, ok := lookupItem(clouds, name).(yaml.MapSlice); ok {
+		entry = mergeEntry(existing, entry, isCloudKey)
+	}
+
+	f.setClouds(setItem(clouds, name, entry))
+	delete(f.removed, name)
+
+	return nil
+}
+
+// RemoveCloud removes a cloud entry from the document. It reports whether
+// the entry existed.
+func (f *CloudsFile) RemoveCloud(name string) bool {
+	clouds, ok := removeItem(f.clouds(), name)
+	if ok {
+		f.setClouds(clouds)
+
+		if f.removed == nil {
+			f.removed = make(map[string]bool)
+		}
+		f.removed[name] = true
+	}
+	return ok
+}
+
+// MarshalYAML implements the yaml.Marshaler interface.
+func (f *CloudsFile) MarshalYAML() (interface{}, error) {
+	return f.doc, nil
+}
+
+// Write writes the document to the file with 0600 permissions.
+func (f *CloudsFile) Write(filename string) error {
+	return writeYAMLFile(filename, f.doc)
+}
+
+// WriteWithSecureFile writes the document without passwords, tokens and
+// application credential secrets to the clouds.yaml file and merges these
+// secrets into the secure.yaml file. Secrets of a cloud in secure.yaml are
+// only replaced if the cloud entry of the document has secrets. Secrets of
+// clouds removed with RemoveCloud are removed from the secure.yaml file.
+// Both files are written with 0600 permissions.
+//
+// Only the auth section of a cloud entry is split. Secrets in the auth
+// section of a region, under regions[].values.auth, are written to the
+// clouds.yaml file, because the regions of secure.yaml are appended to the
+// regions of the cloud entry instead of being merged with them when the
+// files are loaded.
+func (f *CloudsFile) WriteWithSecureFile(filename, secureFilename string) error {
+	secure, err := ReadCloudsFile(secureFilename)
+	if err != nil {
+		return err
+	}
+
+	public := &CloudsFile{doc: copyMapSlice(f.doc)}
+	clouds := public.clouds()
+	for i, item := range clouds {
+		name, ok := item.Key.(string)
+		if !ok {
+			continue
+		}
+
+		entry, _ := item.Value.(yaml.MapSlice)
+		auth, _ := lookupItem(entry, "auth").(yaml.MapSlice)
+
+		var secrets yaml.MapSlice
+		for _, key := range secretKeys {
+			if v := lookupItem(auth, key); v != nil {
+				secrets = append(secrets, yaml.MapItem{Key: key, Value: v})
+				auth, _ = removeItem(auth, key)
+			}
+		}
+
+		// Clouds read from clouds.yaml usually have no secrets since they
+		// are kept in secure.yaml, so only replace secrets that were found.
+		if len(secrets) > 0 {
+			clouds[i].Value = setOrRemoveItem(entry, "auth", auth)
+			secure.setSecrets(name, secrets)
+		}
+	}
+	public.setClouds(clouds)
+
+	for name := range f.removed {
+		secure.setSecrets(name, nil)
+	}
+
+	if err := public.Write(filename); err != nil {
+		return err
+	}
+
+	return secure.Write(secureFilename)
+}
+
+// setSecrets replaces the secrets of the cloud entry.
+func (f *CloudsFile) setSecrets(name string, secrets yaml.MapSlice) {
+	clouds := f.clouds()
+	entry, _ := lookupItem(clouds, name).(yaml.MapSlice)
+	auth, _ := lookupItem(entry, "auth").(yaml.MapSlice)
+
+	for _, key := range secretKeys {
+		auth, _ = removeItem(auth, key)
+	}
+	auth = append(auth, secrets...)
+
+	entry = setOrRemoveItem(entry, "auth", auth)
+	f.setClouds(setOrRemoveItem(clouds, name, entry))
+}
+
+// clouds returns the clouds section of the document.
+func (f *CloudsFile) clouds() yaml.MapSlice {
+	clouds, _ := lookupItem(f.doc, "clouds").(yaml.MapSlice)
+	return clouds
+}
+
+// setClouds replaces the clouds section of the document.
+func (f *CloudsFile) setClouds(clouds yaml.MapSlice) {
+	if clouds == nil {
+		clouds = yaml.MapSlice{}
+	}
+	f.doc = setItem(f.doc, "clouds", clouds)
+}
+
+// writeYAMLFile encodes the value and writes it to the file with 0600
+// permissions. The content is written to a temporary file in the same
+// directory that replaces the file, so the file is never left partially
+// written.
+func writeYAMLFile(filename string, v interface{}) error {
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("unable to encode %s: %s", filename, err)
+	}
+
+	// Replace the target of a symlink instead of the symlink itself.
+	if target, err := filepath.EvalSymlinks(filename); err == nil {
+		filename = target
+	}
+
+	file, err := ioutil.TempFile(filepath.Dir(filename), "."+filepath.Base(filename)+".")
+	if err != nil {
+		return err
+	}
+
+	if err := writeSyncedFile(file, b); err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+
+	if err := os.Rename(file.Name(), filename); err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+
+	return nil
+}
+
+// writeSyncedFile restricts the permissions of the file before writing
+// secrets to it, writes the content and flushes it to the disk.
+func writeSyncedFile(file *os.File, b []byte) error {
+	if err := file.Chmod(0600); err != nil {
+		file.Close()
+		return err
+	}
+
+	if _, err := file.Write(b); err != nil {
+		file.Close()
+		return err
+	}
+
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
+
+// isCloudKey reports whether the key of a cloud entry is modeled by the Cloud
+// struct.
+func isCloudKey(key string) bool {
+	if strings.HasSuffix(key, apiVersionSuffix) ||
+		strings.HasSuffix(key, endpointOverrideSuffix) ||
+		strings.HasSuffix(key, serviceTypeSuffix) {
+		return true
+	}
+	return hasYAMLKey(reflect.TypeOf(cloud{}), key)
+}
+
+// isAuthKey reports whether the key of an auth section is modeled by the
+// AuthInfo struct.
+func isAuthKey(key string) bool {
+	return hasYAMLKey(reflect.TypeOf(AuthInfo{}), key)
+}
+
+// hasYAMLKey reports whether a field of the struct is encoded with the key.
+func hasYAMLKey(t reflect.Type, key string) bool {
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("yaml"), ",")[0]
+		if name == key && name != "-" {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeEntry returns the items of the entry with the modeled keys replaced
+// by the items of the update. Keys that aren't modeled are kept, the auth
+// section is merged the same way.
+func mergeEntry(entry, update yaml.MapSlice, isModeled func(string) bool) yaml.MapSlice {
+	var merged yaml.MapSlice
+	for _, item := range entry {
+		key, ok := item.Key.(string)
+		if !ok {
+			merged = append(merged, item)
+			continue
+		}
+
+		v := lookupItem(update, key)
+		switch {
+		case v != nil:
+			if key == "auth" {
+				existing, ok1 := item.Value.(yaml.MapSlice)
+				auth, ok2 := v.(yaml.MapSlice)
+				if ok1 && ok2 {
+					v = mergeEntry(existing, auth, isAuthKey)
+				}
+			}
+			merged = append(merged, yaml.MapItem{Key: key, Value: v})
+		case !isModeled(key):
+			merged = append(merged, item)
+		}
+	}
+
+	for _, item := range update {
+		if lookupItem(merged, item.Key) == nil {
+			merged = append(merged, item)
+		}
+	}
+
+	return merged
+}
+
+// lookupItem returns the value of the key or nil.
+func lookupItem(ms yaml.MapSlice, key interface{}) interface{} {
+	for _, item := range ms {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return nil
+}
+
+// setItem replaces the value of the key or appends a new item.
+func setItem(ms yaml.MapSlice, key, value interface{}) yaml.MapSlice {
+	for i, item := range ms {
+		if item.Key == key {
+			ms[i].Value = value
+			return ms
+		}
+	}
+	return append(ms, yaml.MapItem{Key: key, Value: value})
+}
+
+// setOrRemoveItem sets the value of the key or removes the key if the value
+// is empty.
+func setOrRemoveItem(ms yaml.MapSlice, key string, value yaml.MapSlice) yaml.MapSlice {
+	if len(value) == 0 {
+		ms, _ = removeItem(ms, key)
+		return ms
+	}
+	return setItem(ms, key, value)
+}
+
+// removeItem removes the key. It reports whether the key existed.
+func removeItem(ms yaml.MapSlice, key interface{}) (yaml.MapSlice, bool) {
+	for i, item := range ms {
+		if item.Key == key {
+			return append(ms[:i:i], ms[i+1:]...), true
+		}
+	}
+	return ms, false
+}
+
+// copyMapSlice returns a deep copy of the nested maps of the value.
+func copyMapSlice(ms yaml.MapSlice) yaml.MapSlice {
+	if ms == nil {
+		return nil
+	}
+
+	c := make(yaml.MapSlice, len(ms))
+	for i, item := range ms {
+		if v, ok := item.Value.(yaml.MapSlice); ok {
+			item.Value = copyMapSlice(v)
+		}
+		c[i] = item
+	}
+	return c
+}